@@ -3,6 +3,8 @@ package main
 import (
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Ealenn/gira/internal/branch"
 	"github.com/Ealenn/gira/internal/command"
@@ -10,6 +12,7 @@ import (
 	"github.com/Ealenn/gira/internal/git"
 	"github.com/Ealenn/gira/internal/issue"
 	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/output"
 	"github.com/Ealenn/gira/internal/ui"
 	"github.com/Ealenn/gira/internal/version"
 
@@ -24,44 +27,126 @@ var (
 	tracker            issue.Tracker
 	currentProfileName string
 	enableAI           bool
+	outputFlag         string
+	offlineFlag        bool
+	refreshFlag        bool
+	profileFlagChanged bool
+	project            *configuration.Project
 )
 
-func preProfile(logger *log.Logger, config *configuration.Configuration) {
+// selectProfile picks the --profile flag, then the profile of the repository .gira file, then the profile mapped to the Git remote
+func selectProfile(logger *log.Logger, config *configuration.Configuration) {
+	ui.CheckConfigurationFile(logger, config)
+	gitManager = git.NewGit(logger)
+
+	if err := findProject(logger); err != nil {
+		logger.Debug("%v", err)
+		logger.Fatal("⚠️  %s\nPlease fix the project configuration, %s", "Project configuration is invalid", err.Error())
+	}
+
+	if !profileFlagChanged {
+		if project != nil && project.Profile != "" {
+			currentProfileName = project.Profile
+		} else if remote, err := gitManager.CurrentRemote(); err == nil {
+			if remoteProfileName := config.GetRemoteProfile(remote.Host, remote.Path()); remoteProfileName != "" {
+				currentProfileName = remoteProfileName
+			}
+		}
+	}
+
 	profile = config.GetProfile(currentProfileName)
 	logger.Debug("Current Profile Name : %s", currentProfileName)
 	logger.Debug("Profile exist : %s", strconv.FormatBool(profile != nil))
+}
 
-	gitManager = git.NewGit(logger)
+// findProject reads the project file of the current repository, project stays nil outside of a repository
+func findProject(logger *log.Logger) error {
+	root, err := gitManager.Root()
+	if err != nil {
+		return nil
+	}
+
+	project, err = configuration.FindProject(logger, root)
+	return err
+}
+
+func preProfile(logger *log.Logger, config *configuration.Configuration) {
+	selectProfile(logger, config)
 
 	if profile != nil {
+		if project != nil {
+			project.Apply(profile)
+		}
+		if err := profile.ResolveTrackerSecret(); err != nil {
+			logger.Warn("⚠️ %s", err.Error())
+		}
+
 		switch profile.Type {
 		case configuration.ProfileTypeJira:
 			tracker = issue.NewJira(logger, profile, gitManager)
 		case configuration.ProfileTypeGithub:
 			tracker = issue.NewGitHub(logger, profile, gitManager)
+		case configuration.ProfileTypeGitlab:
+			tracker = issue.NewGitLab(logger, profile, gitManager)
+		}
+		if tracker != nil {
+			tracker = issue.NewCachedTracker(logger, tracker, currentProfileName, issue.DefaultCacheTTL, getCacheMode())
 		}
 
-		branchManager = branch.NewBranchManager(logger, gitManager, tracker)
+		branchManager = branch.NewBranchManager(logger, gitManager, tracker, profile.Branch)
 	}
 }
 
 func preRun(logger *log.Logger, configuration *configuration.Configuration, version *version.Version) {
 	preProfile(logger, configuration)
 	ui.CheckConfiguration(logger, configuration, currentProfileName, profile)
-	ui.CheckUpdate(logger, configuration, version)
+
+	// Keep machine-readable output clean
+	if getOutputFormat(logger) == "" && !offlineFlag {
+		ui.CheckUpdate(logger, configuration, version)
+	}
+}
+
+func getCacheMode() issue.CacheMode {
+	switch {
+	case offlineFlag:
+		return issue.CacheModeOffline
+	case refreshFlag:
+		return issue.CacheModeRefresh
+	default:
+		return issue.CacheModeDefault
+	}
+}
+
+// getOutputFormat returns the --output format, plain when stdout is not a terminal, or empty to start TUIs
+func getOutputFormat(logger *log.Logger) output.Format {
+	if outputFlag != "" {
+		format, err := output.ParseFormat(outputFlag)
+		if err != nil {
+			logger.Fatal("❌ Unknown output format %s (expected json, yaml, table or plain)", outputFlag)
+		}
+		return format
+	}
+
+	if !output.IsTerminal() {
+		return output.FormatPlain
+	}
+
+	return ""
 }
 
 func main() {
 	logger := log.New(&verbose)
 	version := version.New(logger)
+	configPaths := configuration.Paths()
 	configuration := configuration.New(logger)
 
 	rootCmd := &cobra.Command{
 		Use:     "gira",
 		Version: version.GetCurrentVersion(),
-		Short:   "Gira is a CLI tool that connects your Git workflow with Jira & Github, letting you automate tasks like branch creation and issue updates directly from your terminal.",
+		Short:   "Gira is a CLI tool that connects your Git workflow with Jira, Github & GitLab, letting you automate tasks like branch creation and issue updates directly from your terminal.",
 		Long: `
-Gira is a simple and powerful command-line tool that bridges your Git workflow with Jira & Github.
+Gira is a simple and powerful command-line tool that bridges your Git workflow with Jira, Github & GitLab.
 It helps you automate common development tasks such as creating Git branches from issues, updating issue statuses, or closing issues — all without leaving your terminal.
 With Gira, you can streamline your development processes, eliminate repetitive copy-pasting between Issues and Git, and ensure your issue tracking stays in sync with your commits.
 Use Gira to accelerate your workflow and keep your projects organized more efficiently.
@@ -69,9 +154,16 @@ Use Gira to accelerate your workflow and keep your projects organized more effic
 	}
 
 	// Global
+	rootCmd.PersistentPreRun = func(_ *cobra.Command, _ []string) {
+		profileFlagChanged = rootCmd.PersistentFlags().Changed("profile")
+	}
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "", false, "print detailed operation logs and debug information")
 	rootCmd.PersistentFlags().StringVarP(&currentProfileName, "profile", "p", "default", "configuration profile to use")
 	rootCmd.PersistentFlags().BoolVarP(&enableAI, "ai", "", false, "enable AI-powered features, such as branch name suggestions and other smart assistance")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "print issues and profiles as json, yaml, table or plain instead of interactive views (default plain when not a terminal)")
+	rootCmd.PersistentFlags().BoolVarP(&offlineFlag, "offline", "", false, "serve issues from the local cache only, without calling the tracker")
+	rootCmd.PersistentFlags().BoolVarP(&refreshFlag, "refresh", "", false, "bypass the local cache and fetch issues from the tracker")
+	rootCmd.MarkFlagsMutuallyExclusive("offline", "refresh")
 
 	/* ----------------------
 	 * Branch
@@ -84,7 +176,8 @@ Use Gira to accelerate your workflow and keep your projects organized more effic
 		Short: "Create a new Git branch using issue",
 		Long: `
 Creates a new Git branch based on issue.
-The branch name is generated by combining the issue ID with a slugified version of the issue summary (e.g., "feature/ABC-123/fix-login-bug"). 
+The branch name is generated by combining the issue ID with a slugified version of the issue summary (e.g., "feature/ABC-123/fix-login-bug").
+The naming convention can be changed per profile with a branch template using {type}, {key}, {slug}, {user} and {date} placeholders.
 This helps enforce consistent naming conventions and improve traceability between code and issues.`,
 		Example: "  gira branch ISSUE-123\n  gira branch -a ISSUE-123",
 		Aliases: []string{"checkout"},
@@ -98,11 +191,38 @@ This helps enforce consistent naming conventions and improve traceability betwee
 	branchCommand.Flags().BoolVarP(&branchCommandForceFlag, "force", "f", false, "disable interactive prompts and force branch creation even if checks would normally prevent it")
 	rootCmd.AddCommand(branchCommand)
 
+	/* ----------------------
+	 * Commit
+	 * ----------------------
+	 */
+	var commitCommandForceFlag bool
+	var commitCommand = &cobra.Command{
+		Use:   "commit",
+		Short: "Commit staged changes with a message generated from the current issue",
+		Long: `
+Commits the staged changes with a message built from the issue of the current Git branch.
+
+By default, the message follows the profile commit template (default "{type}({key}): {summary}").
+Available placeholders are {type} (Conventional Commits type from the branch type), {key} (issue ID) and {summary} (issue title).
+
+With --ai, Conventional Commits suggestions are generated from the issue and the staged diff.`,
+		Example: "  gira commit\n  gira commit --ai",
+		Args:    cobra.MinimumNArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			preRun(logger, configuration, version)
+			command.NewCommit(logger, profile, tracker, gitManager, branchManager).Run(enableAI, commitCommandForceFlag)
+		},
+	}
+	commitCommand.Flags().BoolVarP(&commitCommandForceFlag, "force", "f", false, "disable interactive prompts and commit with the generated message")
+	rootCmd.AddCommand(commitCommand)
+
 	/* ----------------------
 	 * Dashboard
 	 * ----------------------
 	 */
 	var dashboardStatusFlag *string
+	var dashboardBoardFlag bool
+	var dashboardWatchFlag time.Duration
 	var dashboardCommand = &cobra.Command{
 		Use:   "dash [issue]",
 		Short: "Open your issue dashboard",
@@ -110,19 +230,83 @@ This helps enforce consistent naming conventions and improve traceability betwee
 This command gives you a project-wide snapshot of your issues without leaving the terminal.
 
 It opens an interactive dashboard that lists issues by status (open, in-progress, or closed).
+With --board (or the "v" key), issues are shown as cards in one lane per status, cards can be moved to the neighbouring lane with "<" and ">".
+Mark issues with "space" (or shift-click) and press "a" to assign, move, label or open them all at once.
+Press "r" to refresh issues, or use --watch to refresh them automatically, new and changed issues are highlighted.
 
 This makes it easy to switch from working on a single issue to seeing the bigger picture of your team's progress.`,
-		Example: "  gira dash",
+		Example: "  gira dash\n  gira dash --board\n  gira dash --watch 1m",
 		Aliases: []string{"dashboard"},
 		Args:    cobra.MinimumNArgs(0),
 		Run: func(_ *cobra.Command, _ []string) {
 			preRun(logger, configuration, version)
-			command.NewDashboard(logger, profile, tracker).Run(dashboardStatusFlag, enableAI)
+			command.NewDashboard(logger, profile, tracker).Run(dashboardStatusFlag, dashboardBoardFlag, dashboardWatchFlag, enableAI, getOutputFormat(logger))
 		},
 	}
 	dashboardStatusFlag = dashboardCommand.Flags().StringP("status", "s", "all", "filter issues by status")
+	dashboardCommand.Flags().BoolVarP(&dashboardBoardFlag, "board", "", false, "open the dashboard as a kanban board with one lane per status")
+	dashboardCommand.Flags().DurationVarP(&dashboardWatchFlag, "watch", "w", 0, "refresh issues at the given interval (e.g. 30s, 5m)")
 	rootCmd.AddCommand(dashboardCommand)
 
+	/* ----------------------
+	 * List
+	 * ----------------------
+	 */
+	var listStatusFlag string
+	var listCommand = &cobra.Command{
+		Use:   "list",
+		Short: "Print your issues without interactive dashboard",
+		Long: `
+Prints the issues of your dashboard, sorted by ID, without starting the interactive dashboard.
+
+The output is a table by default, use --output to print json, yaml or plain (tab separated) issues for scripts.`,
+		Example: "  gira list\n  gira list -s open -o json | jq '.[].id'",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			preRun(logger, configuration, version)
+			command.NewList(logger, profile, tracker).Run(issue.SearchOptions{Status: listStatusFlag}, getOutputFormat(logger))
+		},
+	}
+	listCommand.Flags().StringVarP(&listStatusFlag, "status", "s", "all", "filter issues by status")
+	rootCmd.AddCommand(listCommand)
+
+	/* ----------------------
+	 * Search
+	 * ----------------------
+	 */
+	var searchOptions issue.SearchOptions
+	var searchUpdatedSinceFlag string
+	var searchCommand = &cobra.Command{
+		Use:   "search [text]",
+		Short: "Search issues by status, assignee, type, text or last update",
+		Long: `
+Searches issues on the tracker and prints them, filters are applied by the tracker.
+
+On Jira, filters are added to the JQL of the profile (the board is still required) and types are issue types.
+"open" and "closed" statuses match the status category, other statuses match the status name.
+On GitHub and GitLab, types are labels and statuses are open, closed or all.
+
+Use "me" as assignee to find the issues assigned to you.`,
+		Example: "  gira search login\n  gira search -a me -s open\n  gira search -t bug -t regression --updated-since 7d --sort updated -o json",
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			preRun(logger, configuration, version)
+
+			if len(args) > 0 {
+				searchOptions.Query = args[0]
+			}
+			command.NewSearch(logger, profile, tracker).Run(searchOptions, searchUpdatedSinceFlag, getOutputFormat(logger))
+		},
+	}
+	searchCommand.Flags().StringVarP(&searchOptions.Status, "status", "s", "all", "filter issues by status (open, closed, all or a Jira status name)")
+	searchCommand.Flags().StringVarP(&searchOptions.Assignee, "assignee", "a", "", "filter issues by assignee, \"me\" for your issues")
+	searchCommand.Flags().StringArrayVarP(&searchOptions.Types, "type", "t", nil, "filter issues by Jira issue type or GitHub/GitLab label (repeatable)")
+	searchCommand.Flags().StringVarP(&searchUpdatedSinceFlag, "updated-since", "u", "", "filter issues updated since a date (2025-01-31) or a duration (12h, 7d, 2w)")
+	searchCommand.Flags().StringVarP(&searchOptions.Sort, "sort", "", "", "sort issues by created or updated")
+	searchCommand.Flags().StringVarP(&searchOptions.Order, "order", "", "desc", "sort order, asc or desc")
+	rootCmd.AddCommand(searchCommand)
+
 	/* ----------------------
 	 * Ninja
 	 * ----------------------
@@ -166,7 +350,7 @@ Useful for quickly reviewing the context of your work without leaving the termin
 			if len(args) > 0 {
 				issueID = &args[0]
 			}
-			command.NewIssue(logger, tracker, gitManager, branchManager).Run(issueID, enableAI)
+			command.NewIssue(logger, tracker, gitManager, branchManager).Run(issueID, enableAI, getOutputFormat(logger))
 		},
 	}
 	rootCmd.AddCommand(issueCommand)
@@ -198,6 +382,183 @@ If an issue ID is specified, the command will display information for that issue
 	}
 	rootCmd.AddCommand(openCommand)
 
+	/* ----------------------
+	 * Move
+	 * ----------------------
+	 */
+	var moveCommand = &cobra.Command{
+		Use:   "move [ID] [status]",
+		Short: "Move an issue to another status (from current branch or specified issue ID)",
+		Long: `
+Changes the status of an issue using the transitions available on the tracker.
+
+If no issue ID is provided, the issue associated with the current Git branch is used.
+If no status is provided, an interactive picker lists every available transition.
+
+On Jira, the workflow transitions of the issue are used (e.g., "In Progress", "Done").
+On GitHub, the issue can be closed (as completed or not planned) or reopened.`,
+		Example: "  gira move\n  gira move ABC-123\n  gira move ABC-123 \"In Progress\"",
+		Aliases: []string{"mv", "transition"},
+		Args:    cobra.RangeArgs(0, 2),
+		Run: func(_ *cobra.Command, args []string) {
+			preRun(logger, configuration, version)
+
+			var issueID *string
+			var status *string
+			if len(args) > 0 {
+				issueID = &args[0]
+			}
+			if len(args) > 1 {
+				status = &args[1]
+			}
+			command.NewMove(logger, branchManager, tracker).Run(issueID, status)
+		},
+	}
+	rootCmd.AddCommand(moveCommand)
+
+	/* ----------------------
+	 * Comment
+	 * ----------------------
+	 */
+	var commentCommandMessageFlag string
+	var commentCommand = &cobra.Command{
+		Use:   "comment [ID]",
+		Short: "Comment on an issue (from current branch or specified issue ID)",
+		Long: `
+Posts a comment on an issue.
+
+If no issue ID is provided, the issue associated with the current Git branch is used.
+If no message is provided, an editor is opened to write the comment.
+
+The comment is written in Markdown, it is converted to Jira wiki markup for Jira issues.`,
+		Example: "  gira comment -m \"Fixed in **v1.2.0**\"\n  gira comment ABC-123",
+		Args:    cobra.RangeArgs(0, 1),
+		Run: func(_ *cobra.Command, args []string) {
+			preRun(logger, configuration, version)
+
+			var issueID *string
+			if len(args) > 0 {
+				issueID = &args[0]
+			}
+			command.NewComment(logger, branchManager, tracker).Run(issueID, commentCommandMessageFlag)
+		},
+	}
+	commentCommand.Flags().StringVarP(&commentCommandMessageFlag, "message", "m", "", "comment in Markdown")
+	rootCmd.AddCommand(commentCommand)
+
+	/* ----------------------
+	 * Time
+	 * ----------------------
+	 */
+	var timeCommand = &cobra.Command{
+		Use:   "time",
+		Short: "Track time spent on issues with a local timer",
+		Long: `
+Tracks the time spent on an issue with a local timer, keyed by the issue of the current Git branch.
+
+On Jira, the time is saved as a worklog. On GitHub, it is recorded as a comment.
+On GitLab, it is recorded with the /spend quick action.
+
+A warning is shown when switching branch with "gira branch" while a timer is running on another issue.`,
+		Example: "  gira time start\n  gira time stop -m \"Code review\"\n  gira time log ABC-123 1h30m",
+		Aliases: []string{"worklog"},
+	}
+
+	var timeStartCommand = &cobra.Command{
+		Use:   "start [ID]",
+		Short: "Start a timer on an issue (from current branch or specified issue ID)",
+		Args:  cobra.RangeArgs(0, 1),
+		Run: func(_ *cobra.Command, args []string) {
+			preRun(logger, configuration, version)
+
+			var issueID *string
+			if len(args) > 0 {
+				issueID = &args[0]
+			}
+			command.NewTime(logger, profile, branchManager, tracker).Start(issueID)
+		},
+	}
+	timeCommand.AddCommand(timeStartCommand)
+
+	var timeStopCommandMessageFlag string
+	var timeStopCommand = &cobra.Command{
+		Use:   "stop [ID]",
+		Short: "Stop the timer of an issue and log the time spent",
+		Args:  cobra.RangeArgs(0, 1),
+		Run: func(_ *cobra.Command, args []string) {
+			preRun(logger, configuration, version)
+
+			var issueID *string
+			if len(args) > 0 {
+				issueID = &args[0]
+			}
+			command.NewTime(logger, profile, branchManager, tracker).Stop(issueID, timeStopCommandMessageFlag)
+		},
+	}
+	timeStopCommand.Flags().StringVarP(&timeStopCommandMessageFlag, "message", "m", "", "worklog comment in Markdown")
+	timeCommand.AddCommand(timeStopCommand)
+
+	var timeStatusCommand = &cobra.Command{
+		Use:   "status",
+		Short: "List running timers",
+		Args:  cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			preRun(logger, configuration, version)
+			command.NewTime(logger, profile, branchManager, tracker).Status()
+		},
+	}
+	timeCommand.AddCommand(timeStatusCommand)
+
+	var timeLogCommandMessageFlag string
+	var timeLogCommand = &cobra.Command{
+		Use:     "log [ID] <duration>",
+		Short:   "Log time spent on an issue without timer (e.g. 1h30m)",
+		Example: "  gira time log 45m\n  gira time log ABC-123 \"1h 30m\" -m \"Pair programming\"",
+		Args:    cobra.RangeArgs(1, 2),
+		Run: func(_ *cobra.Command, args []string) {
+			preRun(logger, configuration, version)
+
+			var issueID *string
+			if len(args) > 1 {
+				issueID = &args[0]
+			}
+			command.NewTime(logger, profile, branchManager, tracker).Log(issueID, args[len(args)-1], timeLogCommandMessageFlag)
+		},
+	}
+	timeLogCommand.Flags().StringVarP(&timeLogCommandMessageFlag, "message", "m", "", "worklog comment in Markdown")
+	timeCommand.AddCommand(timeLogCommand)
+	rootCmd.AddCommand(timeCommand)
+
+	/* ----------------------
+	 * Pull Request
+	 * ----------------------
+	 */
+	var pullRequestCommandBaseFlag string
+	var pullRequestCommandDraftFlag bool
+	var pullRequestCommandForceFlag bool
+	var pullRequestCommand = &cobra.Command{
+		Use:   "pr",
+		Short: "Push the current branch and open a GitHub pull request for its issue",
+		Long: `
+Pushes the current Git branch with upstream tracking and opens a GitHub pull request.
+
+The title and body of the pull request come from the issue associated with the current Git branch.
+GitHub issues are closed on merge with a "Closes #N" reference, Jira issues are linked with their key.
+
+With --ai, the description is generated from the commit log and the diff of the branch.`,
+		Example: "  gira pr\n  gira pr --draft\n  gira pr --base develop --ai",
+		Aliases: []string{"pull-request"},
+		Args:    cobra.MinimumNArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			preRun(logger, configuration, version)
+			command.NewPullRequest(logger, profile, tracker, gitManager, branchManager).Run(pullRequestCommandBaseFlag, pullRequestCommandDraftFlag, enableAI, pullRequestCommandForceFlag)
+		},
+	}
+	pullRequestCommand.Flags().StringVarP(&pullRequestCommandBaseFlag, "base", "b", "", "branch the pull request is merged into (default branch of the repository if empty)")
+	pullRequestCommand.Flags().BoolVarP(&pullRequestCommandDraftFlag, "draft", "d", false, "open the pull request as a draft")
+	pullRequestCommand.Flags().BoolVarP(&pullRequestCommandForceFlag, "force", "f", false, "disable interactive prompts and open the pull request directly")
+	rootCmd.AddCommand(pullRequestCommand)
+
 	/* ----------------------
 	 * Config
 	 * ----------------------
@@ -216,14 +577,131 @@ This information is stored in your configuration file and enables Gira to commun
 		Aliases: []string{"configure"},
 		Args:    cobra.MinimumNArgs(0),
 		Run: func(_ *cobra.Command, _ []string) {
-			preProfile(logger, configuration)
-			command.NewConfig(logger, configuration, profile).Run(currentProfileName, configListFlag, configRemoveFlag)
+			// Project settings are not saved in the user configuration
+			selectProfile(logger, configuration)
+			command.NewConfig(logger, configuration, profile).Run(currentProfileName, configListFlag, configRemoveFlag, getOutputFormat(logger))
 		},
 	}
 	configCommand.Flags().BoolVarP(&configListFlag, "list", "l", false, "list all available profiles")
 	configCommand.Flags().BoolVarP(&configRemoveFlag, "remove", "r", false, "remove selected profile")
+
+	completeConfigPaths := func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return configPaths, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var configSetCommand = &cobra.Command{
+		Use:   "set <field> <value>",
+		Short: "Set a field of the profile without interactive prompts",
+		Long: `
+Sets a field of the profile, the profile is created when it doesn't exist.
+Fields are dotted paths of the configuration file, lists are comma separated and maps are key=value pairs.
+Tokens are saved in the OS keyring like with the interactive configuration, use "-" to read the value from stdin and keep it out of your shell history.
+Git remotes are mapped to profiles with remotes.<host/owner> <profile>, an empty profile removes the mapping.
+
+Fields: ` + strings.Join(configPaths, ", "),
+		Example:           "  gira config set -p work type jira\n  gira config set -p work jira.host https://jira.example.com\n  pass show jira | gira config set -p work jira.token -\n  gira config set -p work branch.types.story feature\n  gira config set remotes.github.com/acme work",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeConfigPaths,
+		Run: func(_ *cobra.Command, args []string) {
+			selectProfile(logger, configuration)
+			command.NewConfig(logger, configuration, profile).RunSet(currentProfileName, args[0], args[1])
+		},
+	}
+	configCommand.AddCommand(configSetCommand)
+
+	var configGetShowSecretsFlag bool
+	var configGetCommand = &cobra.Command{
+		Use:               "get <field>",
+		Short:             "Print a field of the profile",
+		Example:           "  gira config get -p work jira.host",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeConfigPaths,
+		Run: func(_ *cobra.Command, args []string) {
+			selectProfile(logger, configuration)
+			command.NewConfig(logger, configuration, profile).RunGet(currentProfileName, args[0], configGetShowSecretsFlag)
+		},
+	}
+	configGetCommand.Flags().BoolVarP(&configGetShowSecretsFlag, "show-secrets", "", false, "print tokens instead of their reference or a redacted value")
+	configCommand.AddCommand(configGetCommand)
+
+	var configExportShowSecretsFlag bool
+	var configExportCommand = &cobra.Command{
+		Use:   "export",
+		Short: "Print profiles as JSON or YAML to import them on another machine",
+		Long: `
+Prints every profile, or the profile selected with --profile, as JSON (default) or YAML with --output.
+Plaintext tokens are redacted unless --show-secrets is set, references to the OS keyring, environment variables or credential helpers are kept.`,
+		Example: "  gira config export > profiles.json\n  gira config export -p work -o yaml",
+		Args:    cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			selectProfile(logger, configuration)
+
+			format := output.FormatJSON
+			if outputFlag != "" {
+				format = getOutputFormat(logger)
+			}
+			command.NewConfig(logger, configuration, profile).RunExport(currentProfileName, !profileFlagChanged, configExportShowSecretsFlag, format)
+		},
+	}
+	configExportCommand.Flags().BoolVarP(&configExportShowSecretsFlag, "show-secrets", "", false, "export tokens instead of their reference or a redacted value")
+	configCommand.AddCommand(configExportCommand)
+
+	var configImportFromEnvFlag bool
+	var configImportCommand = &cobra.Command{
+		Use:   "import [file]",
+		Short: "Save profiles from a JSON or YAML export, or from environment variables",
+		Long: `
+Saves the profiles of a file exported with "gira config export" (stdin when the file is empty or "-"), existing profiles with the same name are replaced.
+Redacted tokens keep the token of the existing profile.
+
+With --from-env, the profile selected with --profile is built from GIRA_IMPORT_<FIELD> environment variables, e.g. GIRA_IMPORT_TYPE, GIRA_IMPORT_JIRA_HOST or GIRA_IMPORT_JIRA_TOKEN.`,
+		Example: "  gira config import profiles.json\n  cat profiles.yaml | gira config import\n  GIRA_IMPORT_TYPE=gitlab GIRA_IMPORT_GITLAB_HOST=https://gitlab.com GIRA_IMPORT_GITLAB_TOKEN=... gira config import --from-env -p ci",
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			selectProfile(logger, configuration)
+
+			file := ""
+			if len(args) > 0 {
+				file = args[0]
+			}
+			command.NewConfig(logger, configuration, profile).RunImport(currentProfileName, file, configImportFromEnvFlag)
+		},
+	}
+	configImportCommand.Flags().BoolVarP(&configImportFromEnvFlag, "from-env", "", false, "build the profile from GIRA_IMPORT_<FIELD> environment variables")
+	configCommand.AddCommand(configImportCommand)
+
 	rootCmd.AddCommand(configCommand)
 
+	/* ----------------------
+	 * Doctor
+	 * ----------------------
+	 */
+	var doctorCommand = &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the configuration, connectivity and credentials of your profiles",
+		Long: `
+Diagnoses why Gira can't reach your tracker, and prints a pass/fail report with hints to fix each failure:
+the config file (syntax, permissions, plaintext tokens), then for every profile, or only the one selected with --profile,
+DNS and TLS to the host, authentication, token scopes, the Jira board and JQL, and finally the Git remote, the AI endpoint and the browser opener.
+
+The command exits with 1 when a check fails.`,
+		Example: "  gira doctor\n  gira doctor -p work",
+		Args:    cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			gitManager = git.NewGit(logger)
+			projectErr := findProject(logger)
+			// An invalid file is reported by the checks instead of stopping the command
+			if configuration.Err == nil && projectErr == nil {
+				selectProfile(logger, configuration)
+			}
+			command.NewDoctor(logger, configuration, gitManager, project, projectErr).Run(currentProfileName, !profileFlagChanged)
+		},
+	}
+	rootCmd.AddCommand(doctorCommand)
+
 	/* ----------------------
 	 * Version
 	 * ----------------------
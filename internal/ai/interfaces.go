@@ -4,7 +4,8 @@ import "github.com/Ealenn/gira/internal/issue"
 
 type Agent interface {
 	BranchNames(issue *issue.Issue) ([]string, error)
-	CommitNames(issue *issue.Issue) ([]string, error)
+	CommitNames(issue *issue.Issue, diff string) ([]string, error)
 	IssueSummary(issue *issue.Issue) (string, error)
+	PullRequestDescription(issue *issue.Issue, commits string, diff string) (string, error)
 	IssueRewrite(context string, text string) (string, error)
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/openai/openai-go/v2"
 	"github.com/openai/openai-go/v2/option"
@@ -64,16 +65,17 @@ func (agent *OpenAI) BranchNames(issue *issue.Issue) ([]string, error) {
 	return respose, nil
 }
 
-func (agent *OpenAI) CommitNames(issue *issue.Issue) ([]string, error) {
+func (agent *OpenAI) CommitNames(issue *issue.Issue, diff string) ([]string, error) {
 	prompt := fmt.Sprintf(
 		"Based on this Ticket:\n"+
 			"Title: %s\nDescription: %s\n\n"+
-			"Generate exactly 3 concise git commit messages following the Conventional Commits specification, based on this ticket.\n"+
+			"And these staged changes:\n%s\n\n"+
+			"Generate exactly 3 concise git commit messages following the Conventional Commits specification, based on this ticket and changes.\n"+
 			"- Allowed types: feat, fix, docs, style, refactor, perf, test, chore.\n"+
 			"- Scope is optional but must be lowercase if present.\n"+
 			"- Message should be short, imperative, and descriptive.\n"+
 			"Return ONLY a valid JSON array of strings, not markdown, e.g. [\"fix(auth): resolve login bug after password reset\", \"feat: improve session handling\", \"chore: update dependencies\"].",
-		issue.Title, agent.getShortIssueDescription(issue),
+		issue.Title, agent.getShortIssueDescription(issue), agent.getShortDiff(diff),
 	)
 	return agent.askJSONStringArray(prompt)
 }
@@ -89,6 +91,22 @@ func (agent *OpenAI) IssueSummary(issue *issue.Issue) (string, error) {
 	return agent.askString(prompt)
 }
 
+func (agent *OpenAI) PullRequestDescription(issue *issue.Issue, commits string, diff string) (string, error) {
+	prompt := fmt.Sprintf(
+		"Based on this Ticket:\n"+
+			"Title: %s\nDescription: %s\n\n"+
+			"And these commits:\n%s\n\n"+
+			"And this diff:\n%s\n\n"+
+			"Generate a concise pull request description:\n"+
+			"- start with a short summary of the change\n"+
+			"- then list the main changes, one per line starting with '- '\n"+
+			"- do not add a title, do not invent changes that are not in the commits or diff\n"+
+			"Return ONLY the description",
+		issue.Title, agent.getShortIssueDescription(issue), commits, agent.getShortDiff(diff),
+	)
+	return agent.askString(prompt)
+}
+
 func (agent *OpenAI) IssueRewrite(context string, text string) (string, error) {
 	prompt := fmt.Sprintf(
 		"Rewrite the following issue text to improve clarity, spelling, and precision:\n"+
@@ -100,6 +118,22 @@ func (agent *OpenAI) IssueRewrite(context string, text string) (string, error) {
 	return agent.askString(prompt)
 }
 
+// Models returns the models listed by the endpoint, without retry to check quickly that it is reachable
+func (agent *OpenAI) Models() ([]string, error) {
+	page, err := agent.client.Models.List(context.TODO(), option.WithMaxRetries(0), option.WithRequestTimeout(10*time.Second))
+	if err != nil {
+		agent.logger.Debug("OpenAI models error %s", err)
+		return nil, err
+	}
+
+	models := []string{}
+	for _, model := range page.Data {
+		models = append(models, model.ID)
+	}
+
+	return models, nil
+}
+
 func (agent *OpenAI) getShortIssueDescription(issue *issue.Issue) string {
 	description := issue.Description
 	if len(description) > 4096 {
@@ -109,6 +143,14 @@ func (agent *OpenAI) getShortIssueDescription(issue *issue.Issue) string {
 	return description
 }
 
+func (agent *OpenAI) getShortDiff(diff string) string {
+	if len(diff) > 8192 {
+		diff = diff[:8192]
+	}
+
+	return diff
+}
+
 func (agent *OpenAI) askString(prompt string) (string, error) {
 	systemMessage := openai.SystemMessage("You are a git workflow assistant. Respond **only** with a text. Do not include markdown, backticks, or any other text.")
 	chatCompletion, err := agent.client.Chat.Completions.New(context.TODO(), openai.ChatCompletionNewParams{
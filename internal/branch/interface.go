@@ -1,11 +1,23 @@
 package branch
 
+// Type is the branch prefix (e.g. feature, bugfix, hotfix, docs), any value can be configured per profile
 type Type string
 
-const (
-	Bug     Type = "BUGFIX"
-	Feature Type = "FEATURE"
-)
+const DefaultType Type = "feature"
+
+// DefaultTypes maps issue types or labels to branch types when the profile doesn't override them
+var DefaultTypes = map[string]Type{
+	"bug":           "bugfix",
+	"defect":        "bugfix",
+	"feature":       "feature",
+	"enhancement":   "feature",
+	"story":         "feature",
+	"task":          "feature",
+	"tasks":         "feature",
+	"documentation": "docs",
+	"chore":         "chore",
+	"spike":         "spike",
+}
 
 type Branch struct {
 	Type    Type
@@ -1,26 +1,40 @@
 package branch
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/Ealenn/gira/internal/configuration"
 	"github.com/Ealenn/gira/internal/git"
 	"github.com/Ealenn/gira/internal/issue"
 	"github.com/Ealenn/gira/internal/log"
 )
 
+const DefaultTemplate = "{type}/{key}/{slug}"
+
+// Placeholder patterns used to parse a branch name from the template
+var templatePatterns = map[string]string{
+	"{type}": `(?P<type>[\w-]+?)`,
+	"{key}":  `(?P<key>[A-Za-z][A-Za-z0-9_]*-\d+|\d+)`,
+	"{slug}": `(?P<slug>.+)`,
+	"{user}": `[^/]+`,
+	"{date}": `\d{4}-\d{2}-\d{2}`,
+}
+
 type Manager struct {
-	logger  *log.Logger
-	git     *git.Git
-	tracker issue.Tracker
+	logger   *log.Logger
+	git      *git.Git
+	tracker  issue.Tracker
+	settings configuration.Branch
 }
 
-func NewBranchManager(logger *log.Logger, git *git.Git, tracker issue.Tracker) *Manager {
+func NewBranchManager(logger *log.Logger, git *git.Git, tracker issue.Tracker, settings configuration.Branch) *Manager {
 	return &Manager{
 		logger,
 		git,
 		tracker,
+		settings,
 	}
 }
 
@@ -31,16 +45,29 @@ func (manager *Manager) GetCurrentBranch() *Branch {
 	}
 
 	manager.logger.Debug("🔎 Current branch %s", currentBranch)
-	branchNameParts := strings.Split(currentBranch, `/`)
-	if len(branchNameParts) < 3 {
+	pattern, patternError := manager.getPattern()
+	if patternError != nil {
+		manager.logger.Fatal("❌ Invalid branch pattern or template, %s", patternError.Error())
+	}
+
+	matches := pattern.FindStringSubmatch(currentBranch)
+	if matches == nil || pattern.SubexpIndex("key") < 0 {
 		manager.logger.Fatal("❌ Unable to find issue in branch name %s", currentBranch)
 	}
 
-	return &Branch{
-		Type:    manager.getBranchType([]string{branchNameParts[0]}),
-		IssueID: branchNameParts[1],
-		Title:   branchNameParts[2],
+	branch := &Branch{
+		Type:    manager.getDefaultType(),
+		IssueID: matches[pattern.SubexpIndex("key")],
+		Raw:     currentBranch,
 	}
+	if index := pattern.SubexpIndex("type"); index >= 0 && matches[index] != "" {
+		branch.Type = Type(strings.ToLower(matches[index]))
+	}
+	if index := pattern.SubexpIndex("slug"); index >= 0 {
+		branch.Title = matches[index]
+	}
+
+	return branch
 }
 
 type FromIssueOptions struct {
@@ -48,6 +75,11 @@ type FromIssueOptions struct {
 }
 
 func (manager *Manager) FromIssue(issue *issue.Issue, opts *FromIssueOptions) *Branch {
+	// Branches must be parsed back to find their issue
+	if err := configuration.ValidateBranchTemplate(manager.settings.Template); err != nil {
+		manager.logger.Fatal("❌ %s, fix it with %s", err.Error(), "gira config set branch.template")
+	}
+
 	branchTitle := strings.ToLower(strings.TrimSpace(issue.Title))
 
 	if opts != nil && opts.TitleOverride != "" {
@@ -60,10 +92,17 @@ func (manager *Manager) FromIssue(issue *issue.Issue, opts *FromIssueOptions) *B
 	branchTitle = regexp.MustCompile(`[^\w\s-]`).ReplaceAllString(branchTitle, "")
 	branchTitle = regexp.MustCompile(`-+`).ReplaceAllString(branchTitle, "-")
 	branchTitle = strings.Trim(branchTitle, "-")
+	branchTitle = manager.truncateSlug(branchTitle)
 
 	branchType := manager.getBranchType(issue.Types)
 
-	branchRaw := fmt.Sprintf("%s/%s/%s", strings.ToLower(string(branchType)), strings.ToUpper(issue.ID), strings.ToLower(branchTitle))
+	branchRaw := strings.NewReplacer(
+		"{type}", strings.ToLower(string(branchType)),
+		"{key}", strings.ToUpper(issue.ID),
+		"{slug}", strings.ToLower(branchTitle),
+		"{user}", manager.getUser(),
+		"{date}", time.Now().Format("2006-01-02"),
+	).Replace(manager.getTemplate())
 
 	return &Branch{
 		Type:    branchType,
@@ -73,17 +112,83 @@ func (manager *Manager) FromIssue(issue *issue.Issue, opts *FromIssueOptions) *B
 	}
 }
 
+func (manager *Manager) getTemplate() string {
+	if manager.settings.Template != "" {
+		return manager.settings.Template
+	}
+
+	return DefaultTemplate
+}
+
+// getPattern returns the configured regex, or builds one from the template placeholders
+func (manager *Manager) getPattern() (*regexp.Regexp, error) {
+	if manager.settings.Pattern != "" {
+		return regexp.Compile(manager.settings.Pattern)
+	}
+
+	if err := configuration.ValidateBranchTemplate(manager.settings.Template); err != nil {
+		return nil, err
+	}
+
+	pattern := regexp.QuoteMeta(manager.getTemplate())
+	for placeholder, placeholderPattern := range templatePatterns {
+		pattern = strings.Replace(pattern, regexp.QuoteMeta(placeholder), placeholderPattern, 1)
+	}
+
+	return regexp.Compile("^" + pattern + "$")
+}
+
+func (manager *Manager) getUser() string {
+	user, err := manager.git.CurrentUser()
+	if err != nil {
+		manager.logger.Debug("Unable to find Git user %v", err)
+		return "me"
+	}
+
+	user = strings.Join(strings.Fields(strings.ToLower(user)), "-")
+	return regexp.MustCompile(`[^\w.-]`).ReplaceAllString(user, "")
+}
+
+func (manager *Manager) truncateSlug(slug string) string {
+	if manager.settings.MaxSlugLength <= 0 || len(slug) <= manager.settings.MaxSlugLength {
+		return slug
+	}
+
+	// Avoid cutting a word in the middle
+	truncated := slug[:manager.settings.MaxSlugLength]
+	if slug[manager.settings.MaxSlugLength] != '-' {
+		if index := strings.LastIndex(truncated, "-"); index > 0 {
+			truncated = truncated[:index]
+		}
+	}
+	slug = truncated
+
+	return strings.Trim(slug, "-")
+}
+
+// getBranchType returns the prefix of the first issue type (or label) found in the profile mapping, then in the default mapping
 func (manager *Manager) getBranchType(issueTypes []string) Type {
 	for _, issueType := range issueTypes {
-		switch strings.ToLower(issueType) {
-		case "bug":
-			return Type(Bug)
-		case "tasks":
-		case "feature":
-		case "enhancement":
-			return Type(Feature)
+		for configuredType, prefix := range manager.settings.Types {
+			if strings.EqualFold(configuredType, issueType) && prefix != "" {
+				return Type(strings.ToLower(prefix))
+			}
+		}
+	}
+
+	for _, issueType := range issueTypes {
+		if prefix, ok := DefaultTypes[strings.ToLower(issueType)]; ok {
+			return prefix
 		}
 	}
 
-	return Type(Feature)
+	return manager.getDefaultType()
+}
+
+func (manager *Manager) getDefaultType() Type {
+	if manager.settings.DefaultType != "" {
+		return Type(strings.ToLower(manager.settings.DefaultType))
+	}
+
+	return DefaultType
 }
@@ -0,0 +1,114 @@
+package branch
+
+import (
+	"testing"
+
+	"github.com/Ealenn/gira/internal/configuration"
+)
+
+func TestManagerGetPattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings configuration.Branch
+		branch   string
+		wantKey  string
+		wantType string
+		wantSlug string
+	}{
+		{"default template", configuration.Branch{}, "feature/ABC-123/add-login", "ABC-123", "feature", "add-login"},
+		{"default template with github issue", configuration.Branch{}, "bugfix/42/fix-crash", "42", "bugfix", "fix-crash"},
+		{"slug with slashes", configuration.Branch{}, "feature/ABC-1/api/v2", "ABC-1", "feature", "api/v2"},
+		{"user and date", configuration.Branch{Template: "{user}/{date}/{key}-{slug}"}, "jane/2025-01-31/ABC-7-docs", "ABC-7", "", "docs"},
+		{"key only", configuration.Branch{Template: "{key}"}, "ABC-9", "ABC-9", "", ""},
+		{"dots are literal", configuration.Branch{Template: "{type}.{key}"}, "feature.ABC-5", "ABC-5", "feature", ""},
+		{"custom pattern", configuration.Branch{Pattern: `^(?P<key>[A-Z]+-\d+)_(?P<slug>.+)$`}, "ABC-4_cleanup", "ABC-4", "", "cleanup"},
+		{"no issue", configuration.Branch{}, "main", "", "", ""},
+		{"dot instead of separator", configuration.Branch{Template: "{type}.{key}"}, "featureXABC-5", "", "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager := &Manager{settings: test.settings}
+			pattern, err := manager.getPattern()
+			if err != nil {
+				t.Fatalf("getPattern() returned %v", err)
+			}
+
+			matches := pattern.FindStringSubmatch(test.branch)
+			if matches == nil {
+				if test.wantKey != "" {
+					t.Errorf("%s doesn't match %s", test.branch, pattern)
+				}
+				return
+			}
+
+			group := func(name string) string {
+				if index := pattern.SubexpIndex(name); index >= 0 {
+					return matches[index]
+				}
+				return ""
+			}
+			if key, branchType, slug := group("key"), group("type"), group("slug"); key != test.wantKey || branchType != test.wantType || slug != test.wantSlug {
+				t.Errorf("%s = (%q, %q, %q), want (%q, %q, %q)", test.branch, key, branchType, slug, test.wantKey, test.wantType, test.wantSlug)
+			}
+		})
+	}
+}
+
+func TestManagerGetPatternInvalidTemplate(t *testing.T) {
+	for _, template := range []string{"{type}/{slug}", "{key}/{key}"} {
+		manager := &Manager{settings: configuration.Branch{Template: template}}
+		if _, err := manager.getPattern(); err == nil {
+			t.Errorf("getPattern() accepted the template %s", template)
+		}
+	}
+}
+
+func TestManagerTruncateSlug(t *testing.T) {
+	tests := []struct {
+		slug      string
+		maxLength int
+		want      string
+	}{
+		{"add-login-page", 0, "add-login-page"},
+		{"add-login-page", 20, "add-login-page"},
+		{"add-login-page", 9, "add-login"},
+		{"add-login-page", 11, "add-login"},
+		{"add-login-page", 2, "ad"},
+		{"refactoring", 5, "refac"},
+	}
+
+	for _, test := range tests {
+		manager := &Manager{settings: configuration.Branch{MaxSlugLength: test.maxLength}}
+		if slug := manager.truncateSlug(test.slug); slug != test.want {
+			t.Errorf("truncateSlug(%q) with %d = %q, want %q", test.slug, test.maxLength, slug, test.want)
+		}
+	}
+}
+
+func TestManagerGetBranchType(t *testing.T) {
+	tests := []struct {
+		name       string
+		settings   configuration.Branch
+		issueTypes []string
+		want       Type
+	}{
+		{"default mapping", configuration.Branch{}, []string{"Bug"}, "bugfix"},
+		{"first known type", configuration.Branch{}, []string{"backend", "Documentation", "bug"}, "docs"},
+		{"unknown type", configuration.Branch{}, []string{"backend"}, DefaultType},
+		{"no type", configuration.Branch{}, nil, DefaultType},
+		{"profile mapping", configuration.Branch{Types: map[string]string{"bug": "Fix"}}, []string{"Bug"}, "fix"},
+		{"profile mapping first", configuration.Branch{Types: map[string]string{"backend": "be"}}, []string{"bug", "backend"}, "be"},
+		{"empty profile prefix", configuration.Branch{Types: map[string]string{"bug": ""}}, []string{"bug"}, "bugfix"},
+		{"profile default type", configuration.Branch{DefaultType: "Task"}, []string{"backend"}, "task"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager := &Manager{settings: test.settings}
+			if branchType := manager.getBranchType(test.issueTypes); branchType != test.want {
+				t.Errorf("getBranchType(%v) = %s, want %s", test.issueTypes, branchType, test.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package browser
 
 import (
+	"fmt"
 	"os/exec"
 	"runtime"
 	"strings"
@@ -18,25 +19,15 @@ func NewBrowser(logger *log.Logger) *Browser {
 	}
 }
 
-func (browser Browser) Open(url string) {
-	var cmd string
-	var args []string
+func (browser Browser) Open(url string) error {
+	cmd := browser.Command()
+	args := []string{url}
 
-	switch runtime.GOOS {
-	case "windows":
-		cmd = "rundll32"
+	switch cmd {
+	case "rundll32":
 		args = []string{"url.dll,FileProtocolHandler", url}
-	case "darwin":
-		cmd = "open"
-		args = []string{url}
-	default:
-		if browser.isWSL() {
-			cmd = "cmd.exe"
-			args = []string{"/c", "start", url}
-		} else {
-			cmd = "xdg-open"
-			args = []string{url}
-		}
+	case "cmd.exe":
+		args = []string{"/c", "start", url}
 	}
 
 	if len(args) > 1 {
@@ -46,7 +37,24 @@ func (browser Browser) Open(url string) {
 
 	if err := exec.Command(cmd, args...).Start(); err != nil {
 		browser.logger.Debug("Open browser exception %v", err)
-		browser.logger.Fatal("Unable to open link %s", url)
+		return fmt.Errorf("unable to open link %s: %w", url, err)
+	}
+
+	return nil
+}
+
+// Command returns the program opening links on this system
+func (browser Browser) Command() string {
+	switch runtime.GOOS {
+	case "windows":
+		return "rundll32"
+	case "darwin":
+		return "open"
+	default:
+		if browser.isWSL() {
+			return "cmd.exe"
+		}
+		return "xdg-open"
 	}
 }
 
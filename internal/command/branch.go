@@ -1,12 +1,15 @@
 package command
 
 import (
+	"time"
+
 	"github.com/Ealenn/gira/internal/ai"
 	"github.com/Ealenn/gira/internal/branch"
 	"github.com/Ealenn/gira/internal/command/forms"
 	"github.com/Ealenn/gira/internal/git"
 	"github.com/Ealenn/gira/internal/issue"
 	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/timer"
 )
 
 type Branch struct {
@@ -26,7 +29,11 @@ func NewBranch(logger *log.Logger, tracker issue.Tracker, git *git.Git, branch *
 }
 
 func (cmd Branch) Run(issueID string, assign bool, enableAI bool, force bool) {
-	issue := cmd.tracker.GetIssue(issueID)
+	issue, err := cmd.tracker.GetIssue(issueID)
+	if err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to find issue %s", issueID)
+	}
+
 	cmd.RunWithIssue(issue, assign, enableAI, force)
 }
 
@@ -55,6 +62,8 @@ func (cmd Branch) RunWithIssue(issue *issue.Issue, assign bool, enableAI, force
 		forms.NewEditBranch(cmd.logger).Ask("✒️ Tweak branch name before creating?", "", generatedBranch)
 	}
 
+	cmd.warnRunningTimers(generatedBranch.IssueID)
+
 	if cmd.git.IsBranchExist(generatedBranch.Raw) {
 		cmd.logger.Warn("⚠️ Branch named %s already exists", generatedBranch.Raw)
 
@@ -90,3 +99,22 @@ func (cmd Branch) RunWithIssue(issue *issue.Issue, assign bool, enableAI, force
 		}
 	}
 }
+
+// warnRunningTimers reminds timers started on other issues before switching branch
+func (cmd Branch) warnRunningTimers(issueID string) {
+	timers, err := timer.NewStore(cmd.logger).List()
+	if err != nil {
+		cmd.logger.Debug("Unable to read timers %v", err)
+		return
+	}
+
+	for _, runningTimer := range timers {
+		if runningTimer.IssueID != issueID {
+			cmd.logger.Warn("⚠️  Timer still running on %s for %s, stop it with %s",
+				runningTimer.IssueID,
+				issue.FormatDuration(time.Since(runningTimer.StartedAt)),
+				"gira time stop "+runningTimer.IssueID,
+			)
+		}
+	}
+}
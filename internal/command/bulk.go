@@ -0,0 +1,147 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Ealenn/gira/internal/branch"
+	"github.com/Ealenn/gira/internal/command/forms"
+	"github.com/Ealenn/gira/internal/issue"
+	"github.com/Ealenn/gira/internal/log"
+)
+
+type Bulk struct {
+	logger  *log.Logger
+	tracker issue.Tracker
+	branch  *branch.Manager
+}
+
+func NewBulk(logger *log.Logger, tracker issue.Tracker, branch *branch.Manager) *Bulk {
+	return &Bulk{
+		logger,
+		tracker,
+		branch,
+	}
+}
+
+// Run asks for an action, confirms it and applies it to every issue, failures don't stop the other issues
+func (cmd Bulk) Run(issues []*issue.Issue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	action := forms.NewSelectBulkAction(cmd.logger).Ask(
+		fmt.Sprintf("⚡ Choose an action for %d issues", len(issues)),
+		cmd.getSummary(issues),
+	).Action
+
+	var (
+		title string
+		apply func(currentIssue *issue.Issue) error
+	)
+
+	switch action {
+	case forms.BulkActionAssign:
+		title = fmt.Sprintf("👤 Assign %d issues to you?", len(issues))
+		apply = func(currentIssue *issue.Issue) error {
+			return cmd.tracker.SelfAssignIssue(currentIssue.ID)
+		}
+	case forms.BulkActionTransition:
+		transitions := cmd.getTransitions(issues)
+		status := cmd.askStatus(transitions)
+		title = fmt.Sprintf("🔀 Move %d issues to %s?", len(issues), status)
+		apply = func(currentIssue *issue.Issue) error {
+			for _, transition := range transitions[currentIssue.ID] {
+				if strings.EqualFold(transition.Status, status) {
+					return cmd.tracker.TransitionIssue(currentIssue.ID, transition)
+				}
+			}
+			return fmt.Errorf("no transition available to %s", status)
+		}
+	case forms.BulkActionLabel:
+		label := ""
+		forms.NewEditLabel(cmd.logger).Ask("🏷️ Label to add", cmd.getSummary(issues), &label)
+		label = strings.TrimSpace(label)
+		if label == "" {
+			cmd.logger.Fatal("❌ The label is %s", "required")
+		}
+		title = fmt.Sprintf("🏷️ Add label %s to %d issues?", label, len(issues))
+		apply = func(currentIssue *issue.Issue) error {
+			return cmd.tracker.AddLabel(currentIssue.ID, label)
+		}
+	case forms.BulkActionOpen:
+		title = fmt.Sprintf("🌎 Open %d issues in the browser?", len(issues))
+		apply = func(currentIssue *issue.Issue) error {
+			return NewOpen(cmd.logger, cmd.branch, cmd.tracker).OpenIssue(currentIssue)
+		}
+	}
+
+	if !forms.NewConfirm(cmd.logger).Ask(title, cmd.getSummary(issues), forms.TypeConfirm).Confirmed {
+		cmd.logger.Fatal("❌ The operation was %s", "canceled")
+	}
+
+	failures := 0
+	for _, currentIssue := range issues {
+		if err := apply(currentIssue); err != nil {
+			failures++
+			cmd.logger.Debug("%v", err)
+			cmd.logger.Warn("❌ %s : %s", currentIssue.ID, issue.Reason(err))
+			continue
+		}
+		cmd.logger.Info("✅ %s : %s", currentIssue.ID, currentIssue.Title)
+	}
+
+	if failures > 0 {
+		cmd.logger.Fatal("❌ %d of %d issues failed", failures, len(issues))
+	}
+	cmd.logger.Info("✅ %d issues done", len(issues))
+}
+
+func (cmd Bulk) getSummary(issues []*issue.Issue) string {
+	lines := []string{}
+	for _, currentIssue := range issues {
+		lines = append(lines, fmt.Sprintf("- %s [%s] %s", currentIssue.ID, currentIssue.Status, currentIssue.Title))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// getTransitions returns the transitions of every issue, issues in different statuses have different transitions
+func (cmd Bulk) getTransitions(issues []*issue.Issue) map[string][]issue.Transition {
+	transitions := map[string][]issue.Transition{}
+	for _, currentIssue := range issues {
+		issueTransitions, err := cmd.tracker.GetTransitions(currentIssue.ID)
+		if err != nil {
+			cmd.logger.Debug("%v", err)
+			cmd.logger.Warn("❌ Unable to find transitions of %s : %s", currentIssue.ID, issue.Reason(err))
+			continue
+		}
+		transitions[currentIssue.ID] = issueTransitions
+	}
+
+	return transitions
+}
+
+// askStatus asks for a target status among the statuses reachable by at least one issue
+func (cmd Bulk) askStatus(transitions map[string][]issue.Transition) string {
+	statuses := []issue.Transition{}
+	for _, issueTransitions := range transitions {
+		for _, transition := range issueTransitions {
+			if !slices.ContainsFunc(statuses, func(status issue.Transition) bool {
+				return strings.EqualFold(status.Status, transition.Status)
+			}) {
+				statuses = append(statuses, issue.Transition{Name: transition.Status, Status: transition.Status})
+			}
+		}
+	}
+
+	if len(statuses) == 0 {
+		cmd.logger.Fatal("❌ No transition available for the %s issues", "selected")
+	}
+	slices.SortFunc(statuses, func(a, b issue.Transition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return forms.NewSelectTransition(cmd.logger).Ask("🔀 Move issues to", "Issues without a transition to the status will fail", statuses...).Transition.Status
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/Ealenn/gira/internal/branch"
+	"github.com/Ealenn/gira/internal/command/forms"
+	"github.com/Ealenn/gira/internal/issue"
+	"github.com/Ealenn/gira/internal/log"
+)
+
+type Comment struct {
+	logger  *log.Logger
+	branch  *branch.Manager
+	tracker issue.Tracker
+}
+
+func NewComment(logger *log.Logger, branch *branch.Manager, tracker issue.Tracker) *Comment {
+	return &Comment{
+		logger,
+		branch,
+		tracker,
+	}
+}
+
+func (cmd Comment) Run(optionalIssueID *string, message string) {
+	var issueID string
+	if optionalIssueID != nil {
+		issueID = *optionalIssueID
+	} else {
+		issueID = cmd.branch.GetCurrentBranch().IssueID
+	}
+
+	cmd.Post(issueID, message)
+}
+
+// Post adds a Markdown comment to the issue, the message is asked when empty
+func (cmd Comment) Post(issueID string, message string) {
+	if strings.TrimSpace(message) == "" {
+		forms.NewEditComment(cmd.logger).Ask("💬 Comment on issue "+issueID, &message)
+	}
+
+	if strings.TrimSpace(message) == "" {
+		cmd.logger.Fatal("❌ The comment is %s", "empty")
+	}
+
+	if _, err := cmd.tracker.AddComment(issueID, message); err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to comment on issue %s", issueID)
+	}
+
+	cmd.logger.Info("✅ Comment added to issue %s", issueID)
+}
@@ -0,0 +1,135 @@
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Ealenn/gira/internal/ai"
+	"github.com/Ealenn/gira/internal/branch"
+	"github.com/Ealenn/gira/internal/command/forms"
+	"github.com/Ealenn/gira/internal/configuration"
+	"github.com/Ealenn/gira/internal/git"
+	"github.com/Ealenn/gira/internal/issue"
+	"github.com/Ealenn/gira/internal/log"
+)
+
+const DefaultCommitTemplate = "{type}({key}): {summary}"
+
+// conventionalHeader matches the type and the optional scope of a Conventional Commit header
+var conventionalHeader = regexp.MustCompile(`^(\w+)(\([^)]*\))?!?: `)
+
+type Commit struct {
+	logger  *log.Logger
+	profile *configuration.Profile
+	tracker issue.Tracker
+	git     *git.Git
+	branch  *branch.Manager
+}
+
+func NewCommit(logger *log.Logger, profile *configuration.Profile, tracker issue.Tracker, git *git.Git, branch *branch.Manager) *Commit {
+	return &Commit{
+		logger,
+		profile,
+		tracker,
+		git,
+		branch,
+	}
+}
+
+func (cmd Commit) Run(enableAI bool, force bool) {
+	diffStat, err := cmd.git.StagedDiffStat()
+	if err != nil {
+		cmd.logger.Debug("%v", err)
+		cmd.logger.Fatal("❌ Unable to read staged changes")
+	}
+	if diffStat == "" {
+		cmd.logger.Fatal("❌ Nothing to commit, stage your changes with %s", "git add")
+	}
+
+	currentBranch := cmd.branch.GetCurrentBranch()
+	currentIssue, err := cmd.tracker.GetIssue(currentBranch.IssueID)
+	if err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to find issue %s", currentBranch.IssueID)
+	}
+
+	key := cmd.getKey(currentIssue)
+	message := cmd.fromTemplate(currentBranch, currentIssue, key)
+
+	if enableAI {
+		diff, _ := cmd.git.StagedDiff()
+		agent := ai.NewOpenAI(cmd.logger)
+		response, err := agent.CommitNames(currentIssue, diff)
+
+		if err == nil && len(response) > 0 {
+			messages := []string{message}
+			for _, aiGeneratedMessage := range response {
+				messages = append(messages, cmd.addKey(aiGeneratedMessage, key))
+			}
+
+			message = forms.NewSelectCommit(cmd.logger).Ask("🔎 Choose the commit message", diffStat, messages...).Message
+		}
+	}
+
+	if !force {
+		forms.NewEditCommit(cmd.logger).Ask("✒️ Tweak commit message before committing?", diffStat, &message)
+	}
+
+	if strings.TrimSpace(message) == "" {
+		cmd.logger.Fatal("❌ The commit message is %s", "empty")
+	}
+
+	output, err := cmd.git.Commit(message)
+	if err != nil {
+		cmd.logger.Fatal("%s", output)
+	}
+
+	cmd.logger.Info("✅ Committed %s", strings.SplitN(message, "\n", 2)[0])
+}
+
+func (cmd Commit) fromTemplate(currentBranch *branch.Branch, currentIssue *issue.Issue, key string) string {
+	template := DefaultCommitTemplate
+	if cmd.profile.Commit.Template != "" {
+		template = cmd.profile.Commit.Template
+	}
+
+	return strings.NewReplacer(
+		"{type}", cmd.getConventionalType(currentBranch.Type),
+		"{key}", key,
+		"{summary}", strings.TrimSpace(currentIssue.Title),
+	).Replace(template)
+}
+
+func (cmd Commit) getConventionalType(branchType branch.Type) string {
+	switch branchType {
+	case "bugfix", "hotfix", "fix", "bug":
+		return "fix"
+	case "docs", "chore", "refactor", "test", "perf", "style", "ci", "build":
+		return string(branchType)
+	default:
+		return "feat"
+	}
+}
+
+// addKey references the issue in a Conventional Commit message, as the scope when there is none, otherwise in a Refs footer
+func (cmd Commit) addKey(message string, key string) string {
+	if strings.Contains(message, key) {
+		return message
+	}
+
+	if matches := conventionalHeader.FindStringSubmatch(message); matches != nil && matches[2] == "" {
+		return fmt.Sprintf("%s(%s)%s", matches[1], key, message[len(matches[1]):])
+	}
+
+	return fmt.Sprintf("%s\n\nRefs: %s", strings.TrimRight(message, "\n"), key)
+}
+
+// getKey returns the issue reference, GitHub and GitLab issue numbers are prefixed with #
+func (cmd Commit) getKey(currentIssue *issue.Issue) string {
+	if _, err := strconv.Atoi(currentIssue.ID); err == nil {
+		return "#" + currentIssue.ID
+	}
+
+	return currentIssue.ID
+}
@@ -2,12 +2,19 @@ package command
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/Ealenn/gira/internal/command/forms"
 	"github.com/Ealenn/gira/internal/configuration"
 	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/output"
 )
 
+// Prefix of the config get and set paths mapping Git remotes to profiles, e.g. remotes.github.com/acme
+const remotesPathPrefix = "remotes."
+
 type Config struct {
 	logger        *log.Logger
 	configuration *configuration.Configuration
@@ -22,7 +29,7 @@ func NewConfig(logger *log.Logger, configuration *configuration.Configuration, p
 	}
 }
 
-func (cmd Config) Run(profileName string, list bool, remove bool) {
+func (cmd Config) Run(profileName string, list bool, remove bool, format output.Format) {
 	profileExist := true
 	if cmd.profile == nil {
 		profileExist = false
@@ -34,6 +41,12 @@ func (cmd Config) Run(profileName string, list bool, remove bool) {
 	/*
 	 * List
 	 */
+	if list && format != "" {
+		if err := output.NewPrinter(format).Profiles(cmd.configuration.JSON.Profiles); err != nil {
+			cmd.logger.Fatal("❌ Unable to print profiles: %v", err)
+		}
+		return
+	}
 	if list {
 		for _, profile := range cmd.configuration.JSON.Profiles {
 			switch profile.Type {
@@ -41,6 +54,8 @@ func (cmd Config) Run(profileName string, list bool, remove bool) {
 				cmd.logger.Info("- [%s] Type Jira on %s", profile.Name, profile.Jira.Host)
 			case configuration.ProfileTypeGithub:
 				cmd.logger.Info("- [%s] Type GitHub with user %s", profile.Name, profile.Github.User)
+			case configuration.ProfileTypeGitlab:
+				cmd.logger.Info("- [%s] Type GitLab on %s", profile.Name, profile.Gitlab.Host)
 			}
 		}
 		return
@@ -80,3 +95,188 @@ func (cmd Config) Run(profileName string, list bool, remove bool) {
 	}
 	cmd.logger.Info("✅ Done!")
 }
+
+// RunGet prints a field of the profile, tokens are printed as references or redacted unless showSecrets
+func (cmd Config) RunGet(profileName string, path string, showSecrets bool) {
+	if remote, ok := strings.CutPrefix(path, remotesPathPrefix); ok {
+		fmt.Println(cmd.configuration.JSON.Remotes[strings.Trim(remote, "/")])
+		return
+	}
+
+	if cmd.profile == nil {
+		cmd.logger.Fatal("❌ Profile %s doesn't exist", profileName)
+	}
+
+	if configuration.IsSecretPath(path) && showSecrets {
+		if err := cmd.profile.ResolveSecret(strings.TrimSuffix(strings.ToLower(path), ".token")); err != nil {
+			cmd.logger.Warn("⚠️ %s", err.Error())
+		}
+	}
+
+	value, err := cmd.profile.Get(path)
+	if err != nil {
+		cmd.logger.Fatal("❌ %s", err.Error())
+	}
+
+	if configuration.IsSecretPath(path) && !showSecrets {
+		redactedProfile := cmd.getRawProfile().Redacted()
+		value, _ = redactedProfile.Get(path)
+	}
+
+	fmt.Println(value)
+}
+
+// RunSet updates a field of the profile, the profile is created when it doesn't exist, "-" reads the value from stdin
+func (cmd Config) RunSet(profileName string, path string, value string) {
+	if remote, ok := strings.CutPrefix(path, remotesPathPrefix); ok {
+		cmd.setRemote(remote, value)
+		return
+	}
+
+	if cmd.profile == nil {
+		cmd.profile = &configuration.Profile{Name: profileName}
+	}
+
+	if value == "-" {
+		rawValue, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			cmd.logger.Fatal("❌ Unable to read the value from stdin: %v", err)
+		}
+		value = strings.TrimSpace(string(rawValue))
+	}
+
+	if err := cmd.profile.Set(path, value); err != nil {
+		cmd.logger.Fatal("❌ %s", err.Error())
+	}
+
+	if err := cmd.configuration.AddProfile(*cmd.profile); err != nil {
+		cmd.logger.Fatal("❌ Unable to save configuration")
+	}
+	cmd.logger.Info("✅ %s of profile %s updated", path, profileName)
+}
+
+// setRemote maps a Git remote to a profile (remotes.github.com/acme work), an empty profile removes the mapping
+func (cmd Config) setRemote(remote string, profileName string) {
+	if profileName != "" && cmd.findRawProfile(profileName) == nil {
+		cmd.logger.Fatal("❌ Profile %s doesn't exist", profileName)
+	}
+
+	if err := cmd.configuration.SetRemote(remote, profileName); err != nil {
+		cmd.logger.Fatal("❌ Unable to save configuration")
+	}
+	if profileName == "" {
+		cmd.logger.Info("✅ Remote %s no longer mapped", remote)
+		return
+	}
+	cmd.logger.Info("✅ Remote %s mapped to %s", remote, profileName)
+}
+
+// RunExport prints the selected profile, or every profile, tokens are printed as references or redacted unless showSecrets
+func (cmd Config) RunExport(profileName string, all bool, showSecrets bool, format output.Format) {
+	profiles := []configuration.Profile{}
+	for _, jsonProfile := range cmd.configuration.JSON.Profiles {
+		if !all && jsonProfile.Name != profileName {
+			continue
+		}
+
+		if showSecrets {
+			profile := cmd.configuration.GetProfile(jsonProfile.Name)
+			if err := profile.ResolveSecrets(); err != nil {
+				cmd.logger.Warn("⚠️ %s", err.Error())
+			}
+			profiles = append(profiles, *profile)
+		} else {
+			profiles = append(profiles, jsonProfile.Redacted())
+		}
+	}
+
+	if !all && len(profiles) == 0 {
+		cmd.logger.Fatal("❌ Profile %s doesn't exist", profileName)
+	}
+
+	if err := output.NewPrinter(format).ProfileExport(profiles); err != nil {
+		cmd.logger.Fatal("❌ Unable to export profiles: %v", err)
+	}
+}
+
+// RunImport saves profiles exported as JSON or YAML (stdin when file is empty or "-"), or the profile built from GIRA_IMPORT_<FIELD> environment variables
+func (cmd Config) RunImport(profileName string, file string, fromEnv bool) {
+	if fromEnv {
+		if cmd.profile == nil {
+			cmd.profile = &configuration.Profile{Name: profileName}
+		}
+
+		paths, err := cmd.profile.SetFromEnv()
+		if err != nil {
+			cmd.logger.Fatal("❌ %s", err.Error())
+		}
+		if len(paths) == 0 {
+			cmd.logger.Fatal("❌ No environment variable found, expected variables like %s", "GIRA_IMPORT_TYPE, GIRA_IMPORT_JIRA_HOST or GIRA_IMPORT_JIRA_TOKEN")
+		}
+
+		cmd.saveImportedProfile(*cmd.profile)
+		return
+	}
+
+	var (
+		rawContent []byte
+		err        error
+	)
+	if file == "" || file == "-" {
+		rawContent, err = io.ReadAll(os.Stdin)
+	} else {
+		rawContent, err = os.ReadFile(file)
+	}
+	if err != nil {
+		cmd.logger.Fatal("❌ Unable to read %s: %v", file, err)
+	}
+
+	profiles, err := configuration.ParseProfiles(rawContent, profileName)
+	if err != nil {
+		cmd.logger.Debug("%v", err)
+		cmd.logger.Fatal("❌ Unable to parse profiles, expected JSON or YAML exported with %s", "gira config export")
+	}
+
+	// An imported file must not run commands on this machine
+	for _, profile := range profiles {
+		if names := profile.CommandSecrets(); len(names) > 0 {
+			cmd.logger.Fatal("❌ Profile %s reads the %s token with a command, set it after the import with %s", profile.Name, strings.Join(names, ", "), "gira config set -p "+profile.Name+" "+names[0]+".token")
+		}
+	}
+
+	for _, profile := range profiles {
+		profile.KeepSecrets(cmd.findRawProfile(profile.Name))
+		cmd.saveImportedProfile(profile)
+	}
+}
+
+func (cmd Config) saveImportedProfile(profile configuration.Profile) {
+	if err := cmd.configuration.AddProfile(profile); err != nil {
+		cmd.logger.Fatal("❌ Unable to save configuration")
+	}
+
+	if !cmd.configuration.IsValid(&profile) {
+		cmd.logger.Warn("⚠️ Profile %s is incomplete, complete it with %s", profile.Name, "gira config set -p "+profile.Name)
+	}
+	cmd.logger.Info("✅ Profile %s imported", profile.Name)
+}
+
+// getRawProfile returns the profile as saved, with references to secret backends
+func (cmd Config) getRawProfile() configuration.Profile {
+	if rawProfile := cmd.findRawProfile(cmd.profile.Name); rawProfile != nil {
+		return *rawProfile
+	}
+
+	return *cmd.profile
+}
+
+// findRawProfile returns the saved profile without resolving its tokens, nil when it doesn't exist
+func (cmd Config) findRawProfile(profileName string) *configuration.Profile {
+	for _, jsonProfile := range cmd.configuration.JSON.Profiles {
+		if jsonProfile.Name == profileName {
+			return &jsonProfile
+		}
+	}
+
+	return nil
+}
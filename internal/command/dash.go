@@ -1,17 +1,23 @@
 package command
 
 import (
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Ealenn/gira/internal/branch"
 	"github.com/Ealenn/gira/internal/configuration"
 	"github.com/Ealenn/gira/internal/git"
 	"github.com/Ealenn/gira/internal/issue"
 	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/output"
 
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 var (
@@ -26,6 +32,26 @@ var (
 			BorderForeground(lipgloss.Color("240"))
 )
 
+// Markers of the issues that changed since the previous fetch
+const (
+	dashChangeNew     = "✚"
+	dashChangeUpdated = "●"
+)
+
+// Marker of the issues selected for bulk actions
+const dashMarked = "✓"
+
+// Sort keys cycled with "s"
+var dashSortKeys = []string{"ID", "Status", "Created", "Assignee"}
+
+// Width of the optional columns, the title takes the remaining space
+var dashColumnWidths = map[string]int{
+	configuration.DashColumnAssignee: 16,
+	configuration.DashColumnTypes:    14,
+	configuration.DashColumnCreated:  10,
+	configuration.DashColumnPriority: 10,
+}
+
 type Dash struct {
 	logger  *log.Logger
 	tracker issue.Tracker
@@ -33,11 +59,31 @@ type Dash struct {
 	branch  *branch.Manager
 	profile *configuration.Profile
 
-	enableAI bool
-	issues   map[string]*issue.Issue
-	table    table.Model
-	selected *issue.Issue
-	action   string
+	enableAI   bool
+	search     issue.SearchOptions
+	watch      time.Duration
+	issues     map[string]*issue.Issue
+	changes    map[string]string
+	marked     map[string]bool
+	table      table.Model
+	selected   *issue.Issue
+	action     string
+	message    string
+	loading    bool
+	refreshing bool
+	err        error
+
+	sortKey   int
+	filter    textinput.Model
+	filtering bool
+
+	board          bool
+	lanes          []dashLane
+	lane           int
+	card           int
+	statuses       []string
+	statusesLoaded bool
+	rowIssues      []*issue.Issue // Issues of the table rows, in the same order
 
 	width        int
 	height       int
@@ -57,24 +103,150 @@ func NewDashboard(logger *log.Logger, profile *configuration.Profile, tracker is
 	}
 }
 
-func (cmd Dash) Init() tea.Cmd { return nil }
+// Messages sent by the search while the dashboard is running
+type issuesPageMsg []*issue.Issue
+type issuesLoadedMsg struct{ err error }
+
+// Messages sent by the refresh, every issue is fetched again to find removed issues
+type issuesRefreshedMsg struct {
+	issues map[string]*issue.Issue
+	err    error
+}
+type refreshTickMsg struct{}
+
+func (cmd Dash) Init() tea.Cmd {
+	return cmd.tick()
+}
 
 func (cmd Dash) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var teacmd tea.Cmd
-	cmd.table, teacmd = cmd.table.Update(msg)
-
-	if selectedIssue, ok := cmd.issues[cmd.table.SelectedRow()[0]]; ok {
-		cmd.selected = selectedIssue
-	}
 
 	switch m := msg.(type) {
+	case issuesPageMsg:
+		for _, pageIssue := range m {
+			cmd.issues[pageIssue.ID] = pageIssue
+		}
+		cmd.refreshRows()
+		return cmd, nil
+	case cardMovedMsg:
+		cmd.message = "✅ " + m.issueID + " moved to " + m.status
+		if m.err != nil {
+			cmd.logger.Debug("%v", m.err)
+			cmd.message = "❌ Unable to move issue " + m.issueID + " : " + issue.Reason(m.err)
+		} else if movedIssue, ok := cmd.issues[m.issueID]; ok {
+			movedIssue.Status = m.status
+			cmd.refreshRows()
+		}
+		return cmd, nil
+	case boardStatusesMsg:
+		for _, status := range m {
+			cmd.addStatus(status)
+		}
+		cmd.refreshRows()
+		return cmd, nil
+	case issuesLoadedMsg:
+		cmd.loading = false
+		if m.err != nil {
+			cmd.err = m.err
+			return cmd, tea.Quit
+		}
+		if cmd.board {
+			return cmd, cmd.loadStatuses()
+		}
+		return cmd, nil
+	case refreshTickMsg:
+		if cmd.loading || cmd.refreshing {
+			return cmd, cmd.tick()
+		}
+		cmd.refreshing = true
+		return cmd, cmd.refresh()
+	case issuesRefreshedMsg:
+		cmd.refreshing = false
+		if m.err != nil {
+			cmd.logger.Debug("%v", m.err)
+			cmd.message = "❌ Unable to refresh issues : " + issue.Reason(m.err)
+		} else {
+			cmd.setRefreshedIssues(m.issues)
+		}
+		return cmd, cmd.tick()
 	case tea.WindowSizeMsg:
 		cmd.width = m.Width
 		cmd.height = m.Height
 		cmd.resize()
+	case tea.MouseMsg:
+		if m.Shift && m.Button == tea.MouseButtonLeft && m.Action == tea.MouseActionPress && !cmd.board {
+			if clickedIssue := cmd.getIssueAt(m.Y); clickedIssue != nil {
+				cmd.toggleMark(clickedIssue)
+			}
+			return cmd, nil
+		}
 	case tea.KeyMsg:
+		if cmd.filtering {
+			switch m.String() {
+			case "enter":
+				cmd.filtering = false
+				cmd.filter.Blur()
+			case "esc":
+				cmd.filtering = false
+				cmd.filter.Blur()
+				cmd.filter.SetValue("")
+				cmd.refreshRows()
+			default:
+				cmd.filter, teacmd = cmd.filter.Update(msg)
+				cmd.refreshRows()
+			}
+			return cmd, teacmd
+		}
+
+		if cmd.board {
+			if board, teacmd, handled := cmd.updateBoard(m.String()); handled {
+				board.updateSelected()
+				return board, teacmd
+			}
+		}
+
 		switch m.String() {
-		case "ctrl+c", "esc", "q":
+		case "v":
+			cmd.board = !cmd.board
+			cmd.refreshRows()
+			if cmd.board && !cmd.loading {
+				return cmd, cmd.loadStatuses()
+			}
+			return cmd, nil
+		case " ":
+			if cmd.selected != nil {
+				cmd.toggleMark(cmd.selected)
+			}
+			return cmd, nil
+		case "a":
+			if len(cmd.marked) > 0 || cmd.selected != nil {
+				cmd.action = "bulk"
+				return cmd, tea.Quit
+			}
+			return cmd, nil
+		case "r":
+			if cmd.loading || cmd.refreshing {
+				return cmd, nil
+			}
+			cmd.refreshing = true
+			return cmd, cmd.refresh()
+		case "/":
+			cmd.filtering = true
+			return cmd, cmd.filter.Focus()
+		case "s":
+			cmd.sortKey = (cmd.sortKey + 1) % len(dashSortKeys)
+			cmd.refreshRows()
+			return cmd, nil
+		case "esc":
+			// Clear the filter before leaving
+			if cmd.filter.Value() != "" {
+				cmd.filter.SetValue("")
+				cmd.refreshRows()
+				return cmd, nil
+			}
+			cmd.action = ""
+			return cmd, tea.Quit
+		case "ctrl+c", "q":
 			cmd.action = ""
 			return cmd, tea.Quit
 		case "enter":
@@ -83,8 +255,12 @@ func (cmd Dash) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return cmd, tea.Quit
 		case "o":
+			cmd.message = ""
 			if cmd.selected != nil {
-				NewOpen(cmd.logger, cmd.branch, cmd.tracker).Run(&cmd.selected.ID)
+				if err := NewOpen(cmd.logger, cmd.branch, cmd.tracker).OpenIssue(cmd.selected); err != nil {
+					cmd.logger.Debug("%v", err)
+					cmd.message = "❌ Unable to open issue " + cmd.selected.ID + " : " + issue.Reason(err)
+				}
 			}
 			return cmd, func() tea.Msg {
 				return tea.WindowSizeMsg{Width: cmd.width, Height: cmd.height}
@@ -98,16 +274,45 @@ func (cmd Dash) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if !cmd.board {
+		cmd.table, teacmd = cmd.table.Update(msg)
+		cmd.updateSelected()
+	}
+
 	return cmd, teacmd
 }
 
 func (cmd Dash) View() string {
-	content := cmd.table.View()
+	content := cmd.viewTable()
 
 	sel := cmd.table.Cursor()
-	totalItems := len(cmd.issues)
-	footerText := "ESC/Q Quit | ↑/↓ Scroll | Enter View | b Branch | o Open"
-	right := strconv.Itoa(sel+1) + "/" + strconv.Itoa(totalItems) + " "
+	totalItems := len(cmd.table.Rows())
+	footerText := "ESC/Q Quit | ↑/↓ Scroll | Enter View | / Filter | s Sort: " + dashSortKeys[cmd.sortKey] + " | v Board | r Refresh | space Mark | a Actions | b Branch | o Open"
+	if cmd.board {
+		content = cmd.viewBoard(cmd.tableWidth, cmd.tableHeight)
+		sel = cmd.card
+		if cmd.lane < len(cmd.lanes) {
+			totalItems = len(cmd.lanes[cmd.lane].Issues)
+		}
+		footerText = "ESC/Q Quit | ←/→ Lane | ↑/↓ Card | </> Move | Enter View | / Filter | s Sort: " + dashSortKeys[cmd.sortKey] + " | v Table | r Refresh | space Mark | a Actions | b Branch | o Open"
+	}
+	if cmd.filtering || cmd.filter.Value() != "" {
+		footerText = cmd.filter.View()
+	}
+	if cmd.message != "" {
+		footerText = cmd.message
+	} else if cmd.loading {
+		footerText = "⏳ Loading more issues..."
+	} else if cmd.refreshing {
+		footerText = "⏳ Refreshing issues..."
+	}
+	right := strconv.Itoa(min(sel+1, totalItems)) + "/" + strconv.Itoa(totalItems) + " "
+	if len(cmd.marked) > 0 {
+		right = dashMarked + " " + strconv.Itoa(len(cmd.marked)) + " | " + right
+	}
+	if cmd.watch > 0 {
+		right = "⟳ " + cmd.watch.String() + " | " + right
+	}
 	footer := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		footerStyle.Width(max(0, cmd.width-lipgloss.Width(right))).Render(footerText),
@@ -126,40 +331,290 @@ func (cmd Dash) View() string {
    Runner
 -----------------------*/
 
-func (cmd *Dash) Run(dashboardStatusFlag *string, enableAI bool) {
-	cmd.enableAI = enableAI
-	if cmd.profile.Type == configuration.ProfileTypeJira && cmd.profile.Jira.Board == "" {
-		cmd.logger.Fatal("❌ %s\nYou can configure new dashboard with %s", "No dashboard configured", "gira config -p "+cmd.profile.Name)
+func (cmd *Dash) Run(dashboardStatusFlag *string, board bool, watch time.Duration, enableAI bool, format output.Format) {
+	if format != "" {
+		NewList(cmd.logger, cmd.profile, cmd.tracker).Run(issue.SearchOptions{Status: *dashboardStatusFlag}, format)
+		return
 	}
 
-	cmd.issues = cmd.tracker.SearchIssues(*dashboardStatusFlag)
+	checkBoard(cmd.logger, cmd.profile)
 
-	// Build rows
-	rows := make([]table.Row, 0, len(cmd.issues))
-	for _, issue := range cmd.issues {
-		rows = append(rows, table.Row{
-			issue.ID,
-			issue.Title,
-			issue.Status,
-		})
+	if watch < 0 || (watch > 0 && watch < time.Second) {
+		cmd.logger.Fatal("❌ The watch interval %s is too short, use at least 1s", watch.String())
 	}
 
-	// Initial columns (will be resized on first WindowSizeMsg)
-	columns := []table.Column{
-		{Title: "#", Width: 8},
-		{Title: "Title", Width: 50},
-		{Title: "Status", Width: 12},
-	}
-
-	// Create table
+	cmd.enableAI = enableAI
+	cmd.board = board
+	cmd.watch = watch
+	cmd.search = issue.SearchOptions{Status: *dashboardStatusFlag}
+	cmd.issues = map[string]*issue.Issue{}
+	cmd.changes = map[string]string{}
+	cmd.marked = map[string]bool{}
+	cmd.loading = true
+
+	cmd.filter = textinput.New()
+	cmd.filter.Prompt = "/"
+	cmd.filter.Placeholder = "Filter by ID or title"
+
+	// Create table, columns are resized on first WindowSizeMsg
 	cmd.table = table.New(
-		table.WithColumns(columns),
-		table.WithRows(rows),
+		table.WithColumns(cmd.getColumns(50)),
 		table.WithFocused(true),
 		table.WithHeight(10), // temporary; will be recalculated on first resize
 	)
 
-	// Style the table nicely
+	cmd.table.SetStyles(cmd.getTableStyles())
+
+	// Run, issues are added page by page while the search is loading
+	p := tea.NewProgram(cmd, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	go func() {
+		_, err := cmd.tracker.SearchIssues(cmd.search, func(issues []*issue.Issue) {
+			p.Send(issuesPageMsg(issues))
+		})
+		p.Send(issuesLoadedMsg{err})
+	}()
+
+	finalModel, err := p.Run()
+	if err != nil {
+		cmd.logger.Fatal("Gira fatal exception : %v", err)
+	}
+
+	// Actions
+	if dash, ok := finalModel.(Dash); ok {
+		if dash.err != nil {
+			fatalError(cmd.logger, dash.err, "❌ Unable to search issues")
+		}
+
+		switch dash.action {
+		case "branch":
+			if dash.selected != nil {
+				NewBranch(dash.logger, dash.tracker, dash.git, dash.branch).
+					Run(dash.selected.ID, true, false, dash.enableAI)
+			}
+		case "bulk":
+			NewBulk(dash.logger, dash.tracker, dash.branch).Run(dash.getMarkedIssues())
+		}
+	}
+}
+
+// tick schedules the next refresh when the dashboard is watched
+func (cmd Dash) tick() tea.Cmd {
+	if cmd.watch <= 0 {
+		return nil
+	}
+
+	return tea.Tick(cmd.watch, func(time.Time) tea.Msg {
+		return refreshTickMsg{}
+	})
+}
+
+// refresh searches issues again without blocking the dashboard, the cache is bypassed
+func (cmd Dash) refresh() tea.Cmd {
+	tracker, search := issue.Fresh(cmd.tracker), cmd.search
+
+	return func() tea.Msg {
+		issues, err := tracker.SearchIssues(search, nil)
+		return issuesRefreshedMsg{issues, err}
+	}
+}
+
+// setRefreshedIssues replaces the issues and marks the issues that are new or changed since the previous fetch
+func (cmd *Dash) setRefreshedIssues(refreshedIssues map[string]*issue.Issue) {
+	changes := map[string]string{}
+	issues := map[string]*issue.Issue{}
+	for _, refreshedIssue := range refreshedIssues {
+		previousIssue, ok := cmd.issues[refreshedIssue.ID]
+		if !ok {
+			changes[refreshedIssue.ID] = dashChangeNew
+		} else if previousIssue.Status != refreshedIssue.Status ||
+			previousIssue.Title != refreshedIssue.Title ||
+			cmd.getAssignee(previousIssue) != cmd.getAssignee(refreshedIssue) {
+			changes[refreshedIssue.ID] = dashChangeUpdated
+		}
+		issues[refreshedIssue.ID] = refreshedIssue
+	}
+
+	for issueID := range cmd.marked {
+		if _, ok := issues[issueID]; !ok {
+			delete(cmd.marked, issueID)
+		}
+	}
+
+	cmd.issues = issues
+	cmd.changes = changes
+	cmd.message = ""
+	if len(changes) > 0 {
+		cmd.message = "🔄 " + strconv.Itoa(len(changes)) + " issue(s) changed since the last refresh"
+	}
+	cmd.refreshRows()
+}
+
+// refreshRows sorts and filters issues, the selected issue is kept when it is still visible
+func (cmd *Dash) refreshRows() {
+	filter := strings.ToLower(cmd.filter.Value())
+
+	issues := []*issue.Issue{}
+	for _, currentIssue := range output.SortIssues(cmd.issues) {
+		if filter == "" || strings.Contains(strings.ToLower(currentIssue.ID), filter) || strings.Contains(strings.ToLower(currentIssue.Title), filter) {
+			issues = append(issues, currentIssue)
+		}
+	}
+	slices.SortStableFunc(issues, cmd.compareIssues)
+
+	cmd.rowIssues = issues
+	rows := make([]table.Row, 0, len(issues))
+	cursor := 0
+	for index, currentIssue := range issues {
+		if cmd.selected != nil && currentIssue.ID == cmd.selected.ID {
+			cursor = index
+		}
+		rows = append(rows, cmd.getRow(currentIssue))
+	}
+
+	cmd.table.SetRows(rows)
+	cmd.table.SetCursor(cursor)
+	cmd.setLanes(issues)
+	cmd.updateSelected()
+}
+
+func (cmd *Dash) toggleMark(currentIssue *issue.Issue) {
+	if cmd.marked[currentIssue.ID] {
+		delete(cmd.marked, currentIssue.ID)
+	} else {
+		cmd.marked[currentIssue.ID] = true
+	}
+	cmd.refreshRows()
+}
+
+// getMarkedIssues returns the issues of bulk actions, the selected issue is used when no issue is marked
+func (cmd *Dash) getMarkedIssues() []*issue.Issue {
+	if len(cmd.marked) == 0 {
+		if cmd.selected == nil {
+			return nil
+		}
+		return []*issue.Issue{cmd.selected}
+	}
+
+	markedIssues := map[string]*issue.Issue{}
+	for issueID := range cmd.marked {
+		markedIssues[issueID] = cmd.issues[issueID]
+	}
+
+	return output.SortIssues(markedIssues)
+}
+
+// getIssueAt returns the issue of the table row displayed at the given terminal line
+func (cmd *Dash) getIssueAt(y int) *issue.Issue {
+	// Skip the frame border
+	lineRows := cmd.getLineRows()
+	line := y - 1
+	if line < 0 || line >= len(lineRows) || lineRows[line] < 0 {
+		return nil
+	}
+
+	return cmd.rowIssues[lineRows[line]]
+}
+
+// getLineRows returns the index in the table rows of each rendered line, -1 for the header lines.
+// The table doesn't expose its scroll offset, a copy of the table is rendered with the row indexes as only column
+func (cmd *Dash) getLineRows() []int {
+	indexRows := make([]table.Row, 0, len(cmd.rowIssues))
+	for index := range cmd.rowIssues {
+		indexRows = append(indexRows, table.Row{strconv.Itoa(index)})
+	}
+	indexTable := cmd.table
+	indexTable.SetRows(indexRows)
+	indexTable.SetColumns([]table.Column{{Title: "", Width: 10}})
+
+	lineRows := []int{}
+	for _, line := range strings.Split(indexTable.View(), "\n") {
+		index, err := strconv.Atoi(strings.TrimSpace(ansi.Strip(line)))
+		if err != nil || index >= len(cmd.rowIssues) {
+			index = -1
+		}
+		lineRows = append(lineRows, index)
+	}
+
+	return lineRows
+}
+
+// viewTable colors the rows of the issues changed since the previous fetch like the cards of the board, the selected row keeps its style
+func (cmd *Dash) viewTable() string {
+	lines := strings.Split(cmd.table.View(), "\n")
+	lineRows := cmd.getLineRows()
+	for index := 0; index < len(lines) && index < len(lineRows); index++ {
+		if lineRows[index] < 0 {
+			continue
+		}
+		rowIssue := cmd.rowIssues[lineRows[index]]
+		if cmd.selected != nil && rowIssue.ID == cmd.selected.ID {
+			continue
+		}
+		if color, ok := changedCardColors[cmd.changes[rowIssue.ID]]; ok {
+			lines[index] = lipgloss.NewStyle().Foreground(color).Render(ansi.Strip(lines[index]))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (cmd *Dash) updateSelected() {
+	cmd.selected = nil
+	if cmd.board {
+		cmd.selected = cmd.getSelectedCard()
+		return
+	}
+	if selectedRow := cmd.table.SelectedRow(); selectedRow != nil {
+		cmd.selected = cmd.issues[selectedRow[1]]
+	}
+}
+
+// compareIssues compares issues on the sort key, issues are already sorted by ID
+func (cmd *Dash) compareIssues(a, b *issue.Issue) int {
+	switch dashSortKeys[cmd.sortKey] {
+	case "Status":
+		return strings.Compare(strings.ToLower(a.Status), strings.ToLower(b.Status))
+	case "Created":
+		// Newest first
+		return b.CreatedAt.Compare(a.CreatedAt)
+	case "Assignee":
+		return strings.Compare(strings.ToLower(cmd.getAssignee(a)), strings.ToLower(cmd.getAssignee(b)))
+	default:
+		return 0
+	}
+}
+
+func (cmd *Dash) getRow(currentIssue *issue.Issue) table.Row {
+	marker := " "
+	if cmd.marked[currentIssue.ID] {
+		marker = dashMarked
+	}
+
+	row := table.Row{
+		marker + cmd.changes[currentIssue.ID],
+		currentIssue.ID,
+		currentIssue.Title,
+		currentIssue.Status,
+	}
+
+	for _, column := range cmd.profile.Dash.Columns {
+		switch column {
+		case configuration.DashColumnAssignee:
+			row = append(row, cmd.getAssignee(currentIssue))
+		case configuration.DashColumnTypes:
+			row = append(row, strings.Join(currentIssue.Types, ", "))
+		case configuration.DashColumnCreated:
+			row = append(row, currentIssue.CreatedAt.Format(time.DateOnly))
+		case configuration.DashColumnPriority:
+			row = append(row, currentIssue.Priority)
+		}
+	}
+
+	return row
+}
+
+// getTableStyles styles the table nicely
+func (cmd *Dash) getTableStyles() table.Styles {
 	s := table.DefaultStyles()
 	s.Header = s.Header.
 		BorderStyle(lipgloss.NormalBorder()).
@@ -174,25 +629,33 @@ func (cmd *Dash) Run(dashboardStatusFlag *string, enableAI bool) {
 		Background(lipgloss.Color("57")).  // indigo
 		Bold(false)
 
-	cmd.table.SetStyles(s)
+	return s
+}
 
-	// Run
-	p := tea.NewProgram(cmd, tea.WithAltScreen(), tea.WithMouseCellMotion())
-	finalModel, err := p.Run()
-	if err != nil {
-		cmd.logger.Fatal("Gira fatal exception : %v", err)
+func (cmd *Dash) getColumns(titleWidth int) []table.Column {
+	columns := []table.Column{
+		{Title: "", Width: 2},
+		{Title: "#", Width: 10},
+		{Title: "Title", Width: titleWidth},
+		{Title: "Status", Width: 14},
 	}
 
-	// Actions
-	if dash, ok := finalModel.(Dash); ok {
-		switch dash.action {
-		case "branch":
-			if dash.selected != nil {
-				NewBranch(dash.logger, dash.tracker, dash.git, dash.branch).
-					Run(dash.selected.ID, true, false, dash.enableAI)
-			}
+	for _, column := range cmd.profile.Dash.Columns {
+		if width, ok := dashColumnWidths[column]; ok {
+			columns = append(columns, table.Column{Title: strings.ToUpper(column[:1]) + column[1:], Width: width})
 		}
 	}
+
+	return columns
+}
+
+func (cmd *Dash) getAssignee(currentIssue *issue.Issue) string {
+	names := []string{}
+	for _, assignee := range currentIssue.Assignees {
+		names = append(names, assignee.Name)
+	}
+
+	return strings.Join(names, ", ")
 }
 
 func (cmd *Dash) resize() {
@@ -215,16 +678,14 @@ func (cmd *Dash) resize() {
 	cmd.tableHeight = tableH
 
 	// Recompute responsive columns:
-	// Keep ID ~10, Status ~14, Title takes the rest.
-	idW := 10
-	statusW := 14
-	titleW := max(20, tableW-idW-statusW-4) // small fudge for internal padding
-
-	cmd.table.SetColumns([]table.Column{
-		{Title: "#", Width: idW},
-		{Title: "Title", Width: titleW},
-		{Title: "Status", Width: statusW},
-	})
+	// Keep ID ~10, Status ~14 and optional columns, Title takes the rest.
+	columnsW := 0
+	for _, column := range cmd.getColumns(0) {
+		columnsW += column.Width + 2 // cell padding
+	}
+	titleW := max(20, tableW-columnsW)
+
+	cmd.table.SetColumns(cmd.getColumns(titleW))
 
 	// Page size == visible height (minus header row inside the table)
 	cmd.table.SetHeight(tableH)
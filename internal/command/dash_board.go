@@ -0,0 +1,267 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Ealenn/gira/internal/issue"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	boardMinLaneWidth = 28
+	boardCardHeight   = 4 // border + ID + title
+)
+
+var (
+	laneHeaderStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("252")).
+			Background(lipgloss.Color("236")).
+			Padding(0, 1)
+
+	cardStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			Padding(0, 1)
+
+	selectedCardStyle = cardStyle.
+				BorderForeground(lipgloss.Color("57"))
+
+	// Border colors of the cards changed since the previous fetch
+	changedCardColors = map[string]lipgloss.Color{
+		dashChangeNew:     lipgloss.Color("42"),
+		dashChangeUpdated: lipgloss.Color("214"),
+	}
+)
+
+// dashLane groups the issues of the board by status
+type dashLane struct {
+	Status string
+	Issues []*issue.Issue
+}
+
+type cardMovedMsg struct {
+	issueID string
+	status  string
+	err     error
+}
+
+// boardStatusesMsg lists the statuses issues can be moved to, found in their transitions
+type boardStatusesMsg []string
+
+// setLanes groups issues by status, lanes are ordered from to do to done.
+// Lanes of the statuses seen in results or transitions are kept empty, so cards can be moved to them and the layout stays stable
+func (cmd *Dash) setLanes(issues []*issue.Issue) {
+	for _, currentIssue := range issues {
+		cmd.addStatus(currentIssue.Status)
+	}
+
+	lanes := []dashLane{}
+	for _, status := range cmd.statuses {
+		lanes = append(lanes, dashLane{Status: status})
+	}
+	for _, currentIssue := range issues {
+		index := slices.IndexFunc(lanes, func(lane dashLane) bool {
+			return strings.EqualFold(lane.Status, currentIssue.Status)
+		})
+		lanes[index].Issues = append(lanes[index].Issues, currentIssue)
+	}
+
+	slices.SortStableFunc(lanes, func(a, b dashLane) int {
+		if rank := laneRank(a.Status) - laneRank(b.Status); rank != 0 {
+			return rank
+		}
+		return strings.Compare(strings.ToLower(a.Status), strings.ToLower(b.Status))
+	})
+
+	// Keep the selected card when it is still visible
+	cmd.lane, cmd.card = min(cmd.lane, max(0, len(lanes)-1)), 0
+	for laneIndex, lane := range lanes {
+		for cardIndex, currentIssue := range lane.Issues {
+			if cmd.selected != nil && currentIssue.ID == cmd.selected.ID {
+				cmd.lane, cmd.card = laneIndex, cardIndex
+			}
+		}
+	}
+	cmd.lanes = lanes
+}
+
+func (cmd *Dash) addStatus(status string) {
+	if !slices.ContainsFunc(cmd.statuses, func(known string) bool { return strings.EqualFold(known, status) }) {
+		cmd.statuses = append(cmd.statuses, status)
+	}
+}
+
+// loadStatuses finds the statuses without issue in the transitions of one issue per status
+func (cmd *Dash) loadStatuses() tea.Cmd {
+	if cmd.statusesLoaded {
+		return nil
+	}
+	cmd.statusesLoaded = true
+
+	issueIDs := map[string]string{}
+	for _, currentIssue := range cmd.issues {
+		issueIDs[strings.ToLower(currentIssue.Status)] = currentIssue.ID
+	}
+	tracker := cmd.tracker
+	logger := cmd.logger
+
+	return func() tea.Msg {
+		statuses := boardStatusesMsg{}
+		for _, issueID := range issueIDs {
+			transitions, err := tracker.GetTransitions(issueID)
+			if err != nil {
+				logger.Debug("Unable to load transitions of %s %v", issueID, err)
+				continue
+			}
+			for _, transition := range transitions {
+				statuses = append(statuses, transition.Status)
+			}
+		}
+		return statuses
+	}
+}
+
+// laneRank guesses the workflow position of a status, trackers don't share the order of statuses
+func laneRank(status string) int {
+	status = strings.ToLower(status)
+	for _, todo := range []string{"backlog", "to do", "todo", "open", "new"} {
+		if strings.Contains(status, todo) {
+			return 0
+		}
+	}
+	for _, done := range []string{"done", "closed", "resolved", "cancel"} {
+		if strings.Contains(status, done) {
+			return 2
+		}
+	}
+
+	return 1
+}
+
+func (cmd *Dash) getSelectedCard() *issue.Issue {
+	if cmd.lane >= len(cmd.lanes) || cmd.card >= len(cmd.lanes[cmd.lane].Issues) {
+		return nil
+	}
+
+	return cmd.lanes[cmd.lane].Issues[cmd.card]
+}
+
+// updateBoard handles the navigation keys of the board, other keys are shared with the table
+func (cmd Dash) updateBoard(key string) (Dash, tea.Cmd, bool) {
+	switch key {
+	case "left", "h":
+		cmd.lane = max(0, cmd.lane-1)
+	case "right", "l":
+		cmd.lane = min(max(0, len(cmd.lanes)-1), cmd.lane+1)
+	case "up", "k":
+		cmd.card = max(0, cmd.card-1)
+		return cmd, nil, true
+	case "down", "j":
+		if cmd.lane < len(cmd.lanes) {
+			cmd.card = min(max(0, len(cmd.lanes[cmd.lane].Issues)-1), cmd.card+1)
+		}
+		return cmd, nil, true
+	case "<", "shift+left":
+		return cmd, cmd.moveCard(-1), true
+	case ">", "shift+right":
+		return cmd, cmd.moveCard(1), true
+	default:
+		return cmd, nil, false
+	}
+
+	if cmd.lane < len(cmd.lanes) {
+		cmd.card = min(cmd.card, max(0, len(cmd.lanes[cmd.lane].Issues)-1))
+	}
+	return cmd, nil, true
+}
+
+// moveCard transitions the selected issue to the status of the neighbouring lane
+func (cmd *Dash) moveCard(direction int) tea.Cmd {
+	selectedCard := cmd.getSelectedCard()
+	target := cmd.lane + direction
+	if selectedCard == nil || target < 0 || target >= len(cmd.lanes) {
+		return nil
+	}
+
+	cmd.message = "⏳ Moving " + selectedCard.ID + "..."
+	status := cmd.lanes[target].Status
+	tracker := cmd.tracker
+
+	return func() tea.Msg {
+		transitions, err := tracker.GetTransitions(selectedCard.ID)
+		if err != nil {
+			return cardMovedMsg{selectedCard.ID, status, err}
+		}
+
+		for _, transition := range transitions {
+			if strings.EqualFold(transition.Status, status) {
+				return cardMovedMsg{selectedCard.ID, status, tracker.TransitionIssue(selectedCard.ID, transition)}
+			}
+		}
+
+		return cardMovedMsg{selectedCard.ID, status, fmt.Errorf("no transition available to %s", status)}
+	}
+}
+
+func (cmd Dash) viewBoard(width int, height int) string {
+	if len(cmd.lanes) == 0 {
+		return ""
+	}
+
+	// Show the lanes around the selected lane when they don't fit
+	visibleLanes := max(1, min(len(cmd.lanes), width/boardMinLaneWidth))
+	firstLane := min(max(0, cmd.lane-visibleLanes/2), len(cmd.lanes)-visibleLanes)
+	laneWidth := width / visibleLanes
+
+	visibleCards := max(1, (height-1)/boardCardHeight)
+
+	renderedLanes := []string{}
+	for laneIndex := firstLane; laneIndex < firstLane+visibleLanes; laneIndex++ {
+		lane := cmd.lanes[laneIndex]
+
+		firstCard := 0
+		if laneIndex == cmd.lane {
+			firstCard = max(0, cmd.card-visibleCards+1)
+		}
+
+		cards := []string{laneHeaderStyle.Width(laneWidth - 1).Render(fmt.Sprintf("%s (%d)", lane.Status, len(lane.Issues)))}
+		for cardIndex := firstCard; cardIndex < min(len(lane.Issues), firstCard+visibleCards); cardIndex++ {
+			currentIssue := lane.Issues[cardIndex]
+			change := cmd.changes[currentIssue.ID]
+
+			style := cardStyle
+			if color, ok := changedCardColors[change]; ok {
+				style = style.BorderForeground(color)
+			}
+			if laneIndex == cmd.lane && cardIndex == cmd.card {
+				style = selectedCardStyle
+			}
+
+			if cmd.marked[currentIssue.ID] {
+				change = dashMarked + " " + change
+			}
+
+			header := strings.TrimSpace(lipgloss.NewStyle().Bold(true).Render(currentIssue.ID) + " " + change)
+			title := truncate(currentIssue.Title, laneWidth-6)
+			cards = append(cards, style.Width(laneWidth-3).Render(header+"\n"+title))
+		}
+
+		renderedLanes = append(renderedLanes, lipgloss.NewStyle().Width(laneWidth).Height(height).Render(lipgloss.JoinVertical(lipgloss.Left, cards...)))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, renderedLanes...)
+}
+
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if width <= 1 || len(runes) <= width {
+		return text
+	}
+
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,284 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ealenn/gira/internal/ai"
+	"github.com/Ealenn/gira/internal/browser"
+	"github.com/Ealenn/gira/internal/configuration"
+	"github.com/Ealenn/gira/internal/git"
+	"github.com/Ealenn/gira/internal/log"
+)
+
+// doctorTimeout bounds each network check, so an unreachable host doesn't block the report
+const doctorTimeout = 10 * time.Second
+
+// doctorReport prints the checks as they run and counts failures and warnings
+type doctorReport struct {
+	logger   *log.Logger
+	failures int
+	warnings int
+}
+
+func (report *doctorReport) section(title string) {
+	report.logger.Info("\n🩺 %s", title)
+}
+
+func (report *doctorReport) pass(name string, detail string) {
+	report.logger.Info("  ✅ %s: %s", name, detail)
+}
+
+func (report *doctorReport) warn(name string, detail string, hint string) {
+	report.warnings++
+	report.logger.Warn("  ⚠️  %s: %s", name, detail)
+	report.hint(hint)
+}
+
+func (report *doctorReport) fail(name string, detail string, hint string) {
+	report.failures++
+	report.logger.Warn("  ❌ %s: %s", name, detail)
+	report.hint(hint)
+}
+
+func (report *doctorReport) hint(hint string) {
+	if hint != "" {
+		report.logger.Log("     💡 %s", hint)
+	}
+}
+
+type Doctor struct {
+	logger        *log.Logger
+	configuration *configuration.Configuration
+	git           *git.Git
+	project       *configuration.Project
+	projectErr    error
+	report        *doctorReport
+}
+
+func NewDoctor(logger *log.Logger, configuration *configuration.Configuration, git *git.Git, project *configuration.Project, projectErr error) *Doctor {
+	return &Doctor{
+		logger:        logger,
+		configuration: configuration,
+		git:           git,
+		project:       project,
+		projectErr:    projectErr,
+		report:        &doctorReport{logger: logger},
+	}
+}
+
+// Run checks the configuration, every profile (or only profileName) and the tools used by the commands
+func (cmd Doctor) Run(profileName string, all bool) {
+	cmd.checkConfiguration()
+
+	if cmd.configuration.Err == nil {
+		profileNames := []string{profileName}
+		if all {
+			profileNames = []string{}
+			for _, profile := range cmd.configuration.JSON.Profiles {
+				profileNames = append(profileNames, profile.Name)
+			}
+		}
+
+		for _, name := range profileNames {
+			cmd.checkProfile(name, name == profileName)
+		}
+	}
+
+	cmd.checkGit(profileName)
+	cmd.checkAI()
+	cmd.checkBrowser()
+
+	cmd.logger.Log("")
+	switch {
+	case cmd.report.failures > 0:
+		cmd.logger.Fatal("❌ %d checks failed and %d warnings, see the hints above", cmd.report.failures, cmd.report.warnings)
+	case cmd.report.warnings > 0:
+		cmd.logger.Info("✅ No check failed, %d warnings", cmd.report.warnings)
+	default:
+		cmd.logger.Info("✅ Everything looks %s", "good")
+	}
+}
+
+func (cmd Doctor) checkConfiguration() {
+	cmd.report.section("Configuration")
+
+	fileInfo, err := os.Stat(cmd.configuration.Path)
+	if err != nil {
+		cmd.report.fail("Config file", err.Error(), "Run gira config to create it")
+		return
+	}
+
+	if cmd.configuration.Err != nil {
+		cmd.report.fail("Config file", cmd.describeReadError(cmd.configuration.Err), "Fix the JSON of "+cmd.configuration.Path+", or move it away and run gira config to start over")
+	} else {
+		cmd.report.pass("Config file", cmd.configuration.Path)
+	}
+
+	if cmd.configuration.JSON.SchemaVersion > configuration.SchemaVersion() {
+		cmd.report.warn("Schema version", fmt.Sprintf("version %d was written by a newer Gira", cmd.configuration.JSON.SchemaVersion), "Upgrade Gira to use the new settings")
+	}
+
+	// Windows doesn't use Unix permissions, the file is in the user profile
+	if runtime.GOOS != "windows" {
+		if permissions := fileInfo.Mode().Perm(); permissions&0o077 != 0 {
+			cmd.report.fail("Permissions", fmt.Sprintf("%04o, other users can read your tokens", permissions), "Run chmod 600 "+cmd.configuration.Path)
+		} else {
+			cmd.report.pass("Permissions", fmt.Sprintf("%04o", permissions))
+		}
+	}
+
+	if cmd.projectErr != nil {
+		cmd.report.fail("Project file", cmd.projectErr.Error(), "Fix the JSON of the "+configuration.ProjectFileName+" file of the repository")
+	}
+
+	if cmd.configuration.Err != nil {
+		return
+	}
+
+	if len(cmd.configuration.JSON.Profiles) == 0 {
+		cmd.report.fail("Profiles", "no profile configured", "Run gira config to add one")
+		return
+	}
+
+	plaintext := false
+	for _, profile := range cmd.configuration.JSON.Profiles {
+		for _, name := range profile.PlaintextSecrets() {
+			plaintext = true
+			cmd.report.warn("Tokens", fmt.Sprintf("the %s token of profile %s is saved in plaintext", name, profile.Name), "Run gira config -p "+profile.Name+" to move it to the OS keyring, or use an env: or cmd: reference")
+		}
+	}
+	if !plaintext {
+		cmd.report.pass("Tokens", "saved in the OS keyring or referenced")
+	}
+
+	for remote, profileName := range cmd.configuration.JSON.Remotes {
+		if !cmd.hasProfile(profileName) {
+			cmd.report.fail("Remotes", fmt.Sprintf("%s is mapped to the missing profile %s", remote, profileName), "Fix the remotes of "+cmd.configuration.Path)
+		}
+	}
+
+	if cmd.project != nil {
+		if cmd.project.Profile != "" && !cmd.hasProfile(cmd.project.Profile) {
+			cmd.report.fail("Project file", fmt.Sprintf("%s uses the missing profile %s", cmd.project.Path, cmd.project.Profile), "Run gira config -p "+cmd.project.Profile+" to create it")
+		} else {
+			cmd.report.pass("Project file", cmd.project.Path)
+		}
+	}
+}
+
+// describeReadError adds the line of JSON syntax errors
+func (cmd Doctor) describeReadError(err error) string {
+	var syntaxError *json.SyntaxError
+	if !errors.As(err, &syntaxError) {
+		return err.Error()
+	}
+
+	content, readError := os.ReadFile(cmd.configuration.Path)
+	if readError != nil || syntaxError.Offset > int64(len(content)) {
+		return err.Error()
+	}
+
+	line := strings.Count(string(content[:syntaxError.Offset]), "\n") + 1
+	return fmt.Sprintf("invalid JSON line %d, %v", line, err)
+}
+
+func (cmd Doctor) hasProfile(name string) bool {
+	return slices.ContainsFunc(cmd.configuration.JSON.Profiles, func(profile configuration.Profile) bool {
+		return profile.Name == name
+	})
+}
+
+// checkGit checks the origin remote, used to find GitHub and GitLab issues unless the profile sets the repository
+func (cmd Doctor) checkGit(profileName string) {
+	cmd.report.section("Git repository")
+
+	root, err := cmd.git.Root()
+	if err != nil {
+		cmd.report.warn("Repository", "not inside a Git repository", "Run gira doctor in your repository to check its remote")
+		return
+	}
+	cmd.report.pass("Repository", root)
+
+	origin, err := cmd.git.CurrentOrigin()
+	if err != nil {
+		cmd.report.warn("Remote", "no origin remote", "Add it with git remote add origin <url>")
+		return
+	}
+
+	remote, err := git.ParseRemote(origin)
+	if err != nil {
+		if repository := cmd.getRepositoryOverride(profileName); repository != "" {
+			cmd.report.pass("Remote", fmt.Sprintf("%s, profile %s uses %s", err.Error(), profileName, repository))
+			return
+		}
+		cmd.report.fail("Remote", err.Error(), "GitHub and GitLab issues are found from the origin URL, set github.repository or gitlab.project with gira config set to override it")
+		return
+	}
+	cmd.report.pass("Remote", remote.Host+"/"+remote.Path())
+}
+
+// getRepositoryOverride returns the github.repository or gitlab.project setting of the profile, used instead of the origin
+func (cmd Doctor) getRepositoryOverride(profileName string) string {
+	profile := cmd.configuration.GetProfile(profileName)
+	switch {
+	case profile == nil:
+		return ""
+	case profile.Type == configuration.ProfileTypeGitlab:
+		return profile.Gitlab.Project
+	default:
+		return profile.Github.Repository
+	}
+}
+
+func (cmd Doctor) checkAI() {
+	cmd.report.section("AI")
+
+	endpoint := os.Getenv("GIRA_AI_ENDPOINT")
+	model := os.Getenv("GIRA_AI_MODEL")
+	if endpoint == "" && model == "" {
+		cmd.report.pass("Endpoint", "not configured, --ai is disabled")
+		return
+	}
+	if endpoint == "" || model == "" {
+		cmd.report.fail("Endpoint", "GIRA_AI_ENDPOINT and GIRA_AI_MODEL are both required", "See https://github.com/Ealenn/gira#ai-powered-features")
+		return
+	}
+
+	models, err := ai.NewOpenAI(cmd.logger).Models()
+	if err != nil {
+		cmd.report.fail("Endpoint", fmt.Sprintf("unable to reach %s: %v", endpoint, err), "Check GIRA_AI_ENDPOINT and GIRA_AI_APIKEY")
+		return
+	}
+	cmd.report.pass("Endpoint", endpoint)
+
+	if len(models) > 0 && !slices.Contains(models, model) {
+		cmd.report.warn("Model", fmt.Sprintf("%s is not listed by the endpoint", model), "Check GIRA_AI_MODEL, available models: "+strings.Join(models, ", "))
+	} else {
+		cmd.report.pass("Model", model)
+	}
+}
+
+func (cmd Doctor) checkBrowser() {
+	cmd.report.section("Browser")
+
+	command := browser.NewBrowser(cmd.logger).Command()
+	path, err := exec.LookPath(command)
+	if err != nil {
+		hint := ""
+		if command == "xdg-open" {
+			hint = "Install xdg-utils, gira open uses xdg-open to open links"
+		}
+		cmd.report.fail("Opener", command+" not found", hint)
+		return
+	}
+
+	cmd.report.pass("Opener", path)
+}
@@ -0,0 +1,217 @@
+package command
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ealenn/gira/internal/configuration"
+	"github.com/Ealenn/gira/internal/issue"
+	"github.com/Ealenn/gira/internal/network"
+)
+
+// certificateExpiryWarning is how long before the expiry of a server certificate a warning is shown
+const certificateExpiryWarning = 14 * 24 * time.Hour
+
+// checkProfile checks the settings, the host and the credentials of the profile, the project file applies to the current profile
+func (cmd Doctor) checkProfile(profileName string, current bool) {
+	cmd.report.section("Profile " + profileName)
+
+	profile := cmd.configuration.GetProfile(profileName)
+	if profile == nil {
+		cmd.report.fail("Profile", "doesn't exist", "Run gira config -p "+profileName+" to create it")
+		return
+	}
+	cmd.report.pass("Type", strings.ToLower(string(profile.Type)))
+
+	if current && cmd.project != nil {
+		cmd.project.Apply(profile)
+	}
+
+	if err := profile.ResolveTrackerSecret(); err != nil {
+		cmd.report.fail("Token", err.Error(), "Check the reference of the token with gira config get -p "+profile.Name)
+		return
+	}
+
+	if !cmd.configuration.IsValid(profile) {
+		cmd.report.fail("Settings", "invalid host, token or TLS settings", "Run gira config -p "+profile.Name+" to fix them")
+		return
+	}
+	cmd.report.pass("Settings", "valid")
+
+	if profile.TLS.Insecure {
+		cmd.report.warn("TLS", "certificate verification is disabled", "Set the CA bundle of the server with gira config set -p "+profile.Name+" tls.caBundle /path/to/ca.pem instead")
+	}
+
+	if !cmd.checkHost(profile, cmd.getTrackerURL(profile)) {
+		return
+	}
+
+	switch profile.Type {
+	case configuration.ProfileTypeJira:
+		tracker := issue.NewJira(cmd.logger, profile, cmd.git)
+		if cmd.checkAuthentication(profile, tracker) {
+			cmd.checkJiraBoard(profile, tracker)
+		}
+	case configuration.ProfileTypeGithub:
+		if profile.Github.Token == "" {
+			cmd.report.warn("Authentication", "no token, only public repositories with 60 requests per hour", "Add a token with gira config -p "+profile.Name)
+			return
+		}
+		cmd.checkAuthentication(profile, issue.NewGitHub(cmd.logger, profile, cmd.git))
+	case configuration.ProfileTypeGitlab:
+		cmd.checkAuthentication(profile, issue.NewGitLab(cmd.logger, profile, cmd.git))
+	}
+}
+
+func (cmd Doctor) getTrackerURL(profile *configuration.Profile) string {
+	switch profile.Type {
+	case configuration.ProfileTypeJira:
+		return profile.Jira.Host
+	case configuration.ProfileTypeGitlab:
+		return profile.Gitlab.Host
+	default:
+		if profile.Github.BaseURL != "" {
+			return profile.Github.BaseURL
+		}
+		return "https://api.github.com"
+	}
+}
+
+// checkHost resolves the host and connects with the TLS and proxy settings of the profile, false when it is unreachable
+func (cmd Doctor) checkHost(profile *configuration.Profile, rawURL string) bool {
+	hostURL, err := url.Parse(rawURL)
+	if err != nil {
+		cmd.report.fail("Host", err.Error(), "Run gira config -p "+profile.Name+" to fix it")
+		return false
+	}
+	host := hostURL.Hostname()
+	proxyURL, _ := http.ProxyFromEnvironment(&http.Request{URL: hostURL})
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+	addresses, err := net.DefaultResolver.LookupHost(ctx, host)
+	switch {
+	case err != nil && proxyURL == nil:
+		cmd.report.fail("DNS", fmt.Sprintf("unable to resolve %s", host), "Check the host of the profile, your network or VPN")
+		return false
+	case err != nil:
+		cmd.report.warn("DNS", fmt.Sprintf("unable to resolve %s, requests go through the proxy %s", host, proxyURL.Host), "")
+	default:
+		cmd.report.pass("DNS", fmt.Sprintf("%s → %s", host, strings.Join(addresses, ", ")))
+	}
+
+	client, err := network.NewClient(cmd.logger, profile.TLS)
+	if err != nil {
+		cmd.report.fail("TLS", err.Error(), "Check the tls.caBundle, tls.clientCertificate and tls.clientKey files with gira config get -p "+profile.Name)
+		return false
+	}
+	client.Timeout = doctorTimeout
+
+	response, err := client.Get(rawURL)
+	if err != nil {
+		var certificateError *tls.CertificateVerificationError
+		if errors.As(err, &certificateError) {
+			cmd.report.fail("TLS", fmt.Sprintf("certificate of %s not trusted, %v", host, certificateError.Err), "Set the CA bundle of your company with gira config set -p "+profile.Name+" tls.caBundle /path/to/ca.pem")
+			return false
+		}
+
+		hint := "Check your network or VPN"
+		if proxyURL != nil {
+			hint = fmt.Sprintf("Check the proxy %s, or add %s to NO_PROXY", proxyURL.Host, host)
+		}
+		cmd.report.fail("Connection", err.Error(), hint)
+		return false
+	}
+	response.Body.Close()
+
+	if response.TLS == nil {
+		cmd.report.warn("TLS", fmt.Sprintf("the connection to %s is not encrypted", host), "Use an https:// URL with gira config -p "+profile.Name)
+		return true
+	}
+
+	certificate := response.TLS.PeerCertificates[0]
+	if time.Until(certificate.NotAfter) < certificateExpiryWarning {
+		cmd.report.warn("TLS", fmt.Sprintf("certificate of %s expires on %s", host, certificate.NotAfter.Format(time.DateOnly)), "Ask the administrator of "+host+" to renew it")
+	} else {
+		cmd.report.pass("TLS", fmt.Sprintf("certificate of %s valid until %s", host, certificate.NotAfter.Format(time.DateOnly)))
+	}
+
+	return true
+}
+
+// checkAuthentication checks the token and its scopes, false when the tracker rejects it
+func (cmd Doctor) checkAuthentication(profile *configuration.Profile, authenticator issue.Authenticator) bool {
+	user, scopes, err := authenticator.Authenticate()
+	if err != nil {
+		hint := "Run gira doctor --verbose for the details of the error"
+		switch {
+		case errors.Is(err, issue.ErrUnauthorized) && profile.Jira.Auth == configuration.JiraAuthBasic:
+			hint = "Check the email and API token (not your password) with gira config -p " + profile.Name
+		case errors.Is(err, issue.ErrUnauthorized):
+			hint = "The token is invalid or expired, renew it with gira config -p " + profile.Name
+		case errors.Is(err, issue.ErrNotFound):
+			hint = "Check the host of the profile, it should be the root URL of the API"
+		}
+		cmd.report.fail("Authentication", issue.Reason(err), hint)
+		return false
+	}
+	cmd.report.pass("Authentication", "authenticated as "+user)
+
+	switch profile.Type {
+	case configuration.ProfileTypeGithub:
+		cmd.checkScopes(scopes, "repo", "public_repo", "private repositories are not accessible", "https://github.com/settings/tokens")
+	case configuration.ProfileTypeGitlab:
+		cmd.checkScopes(scopes, "api", "read_api", "creating issues, comments and labels fails", profile.Gitlab.Host+"/-/user_settings/personal_access_tokens")
+	}
+
+	return true
+}
+
+// checkScopes fails without the required scope, and warns when only the limited scope is granted
+func (cmd Doctor) checkScopes(scopes []string, required string, limited string, limitation string, settingsURL string) {
+	switch {
+	case scopes == nil:
+		cmd.report.pass("Token scopes", "not listed by the tracker for this token")
+	case slices.Contains(scopes, required):
+		cmd.report.pass("Token scopes", strings.Join(scopes, ", "))
+	case slices.Contains(scopes, limited):
+		cmd.report.warn("Token scopes", fmt.Sprintf("%s only, %s", limited, limitation), fmt.Sprintf("Create a token with the %s scope on %s", required, settingsURL))
+	default:
+		cmd.report.fail("Token scopes", fmt.Sprintf("%s scope missing, got %s", required, strings.Join(scopes, ", ")), fmt.Sprintf("Create a token with the %s scope on %s", required, settingsURL))
+	}
+}
+
+// checkJiraBoard checks the board and the JQL used by the dash, list and search commands
+func (cmd Doctor) checkJiraBoard(profile *configuration.Profile, tracker *issue.JiraTracker) {
+	boardHint := "Set the board ID, found in the board URL (rapidView=42 or /boards/42), with gira config set -p " + profile.Name + " jira.board 42"
+	if profile.Jira.Board == "" {
+		cmd.report.warn("Board", "no board, the dash, list and search commands need one", boardHint)
+		return
+	}
+
+	boardName, err := tracker.GetBoard(profile.Jira.Board)
+	if err != nil {
+		cmd.report.fail("Board", fmt.Sprintf("board %s: %s", profile.Jira.Board, issue.Reason(err)), boardHint)
+		return
+	}
+	cmd.report.pass("Board", fmt.Sprintf("%s (%s)", boardName, profile.Jira.Board))
+
+	if profile.Jira.JQL == "" {
+		cmd.report.pass("JQL", "not set, every issue of the board is listed")
+		return
+	}
+
+	if err := tracker.ValidateJQL(); err != nil {
+		cmd.report.fail("JQL", issue.Reason(err), "Fix the query with gira config set -p "+profile.Name+" jira.jql '<query>'")
+		return
+	}
+	cmd.report.pass("JQL", profile.Jira.JQL)
+}
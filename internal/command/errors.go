@@ -0,0 +1,12 @@
+package command
+
+import (
+	"github.com/Ealenn/gira/internal/issue"
+	"github.com/Ealenn/gira/internal/log"
+)
+
+// fatalError stops the command with an exit code matching the category of the tracker error
+func fatalError(logger *log.Logger, err error, format string, args ...any) {
+	logger.Debug("%v", err)
+	logger.FatalWithCode(issue.ExitCode(err), format+"\n%s", append(args, issue.Reason(err))...)
+}
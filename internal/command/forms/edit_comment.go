@@ -0,0 +1,39 @@
+package forms
+
+import (
+	"github.com/charmbracelet/huh"
+
+	"github.com/Ealenn/gira/internal/log"
+)
+
+type EditComment struct {
+	logger *log.Logger
+	ui     *huh.Form
+}
+
+func NewEditComment(logger *log.Logger) *EditComment {
+	return &EditComment{
+		logger,
+		nil,
+	}
+}
+
+func (form EditComment) Ask(title string, comment *string) {
+	form.ui = huh.NewForm(
+		huh.NewGroup(
+			huh.NewText().
+				Title(title).
+				Description("Markdown is supported").
+				CharLimit(4096).
+				Value(comment),
+		),
+	).WithTheme(huh.ThemeDracula())
+
+	err := form.ui.Run()
+
+	if err != nil {
+		form.logger.Fatal("❌ The operation was %s", "canceled")
+	}
+
+	form.ui.View()
+}
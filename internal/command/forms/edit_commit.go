@@ -0,0 +1,39 @@
+package forms
+
+import (
+	"github.com/charmbracelet/huh"
+
+	"github.com/Ealenn/gira/internal/log"
+)
+
+type EditCommit struct {
+	logger *log.Logger
+	ui     *huh.Form
+}
+
+func NewEditCommit(logger *log.Logger) *EditCommit {
+	return &EditCommit{
+		logger,
+		nil,
+	}
+}
+
+func (form EditCommit) Ask(title string, description string, message *string) {
+	form.ui = huh.NewForm(
+		huh.NewGroup(
+			huh.NewText().
+				Title(title).
+				Description(description).
+				CharLimit(1024).
+				Value(message),
+		),
+	).WithTheme(huh.ThemeDracula())
+
+	err := form.ui.Run()
+
+	if err != nil {
+		form.logger.Fatal("❌ The operation was %s", "canceled")
+	}
+
+	form.ui.View()
+}
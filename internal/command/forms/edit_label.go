@@ -0,0 +1,47 @@
+package forms
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/Ealenn/gira/internal/log"
+)
+
+type EditLabel struct {
+	logger *log.Logger
+	ui     *huh.Form
+}
+
+func NewEditLabel(logger *log.Logger) *EditLabel {
+	return &EditLabel{
+		logger,
+		nil,
+	}
+}
+
+func (form EditLabel) Ask(title string, description string, label *string) {
+	form.ui = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(title).
+				Description(description).
+				Validate(func(value string) error {
+					if strings.TrimSpace(value) == "" {
+						return errors.New("the label is required")
+					}
+					return nil
+				}).
+				Value(label),
+		),
+	).WithTheme(huh.ThemeDracula())
+
+	err := form.ui.Run()
+
+	if err != nil {
+		form.logger.Fatal("❌ The operation was %s", "canceled")
+	}
+
+	form.ui.View()
+}
@@ -2,8 +2,11 @@ package forms
 
 import (
 	"fmt"
+	"os"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 
@@ -11,6 +14,9 @@ import (
 	"github.com/Ealenn/gira/internal/log"
 )
 
+// Tokens are saved in the OS keyring, references to other secret backends are saved as is
+const tokenStorageHint = "Saved in the OS keyring, or enter env:VARIABLE or cmd:<credential helper> (e.g. cmd:pass show gira/token)"
+
 type EditProfile struct {
 	logger *log.Logger
 	ui     *huh.Form
@@ -39,13 +45,29 @@ func (form EditProfile) Ask(profile *configuration.Profile) {
 	/*
 	* Account
 	 */
-	form.ui = form.getAccountForm(profile)
+	maxSlugLength := ""
+	if profile.Branch.MaxSlugLength > 0 {
+		maxSlugLength = strconv.Itoa(profile.Branch.MaxSlugLength)
+	}
+
+	branchTypes := formatBranchTypes(profile.Branch.Types)
+
+	maxResults := ""
+	if profile.Dash.MaxResults > 0 {
+		maxResults = strconv.Itoa(profile.Dash.MaxResults)
+	}
+
+	form.ui = form.getAccountForm(profile, &maxSlugLength, &branchTypes, &maxResults)
 	accountFormErr := form.ui.Run()
 
 	if accountFormErr != nil {
 		form.logger.Fatal("❌ The operation was %s", "canceled")
 	}
 
+	profile.Branch.MaxSlugLength, _ = strconv.Atoi(maxSlugLength)
+	profile.Branch.Types, _ = parseBranchTypes(branchTypes)
+	profile.Dash.MaxResults, _ = strconv.Atoi(maxResults)
+
 	form.ui.View()
 }
 
@@ -57,16 +79,21 @@ func (form EditProfile) getProfileForm(profile *configuration.Profile) *huh.Form
 				Options(
 					huh.Option[configuration.ProfileType]{Key: "Jira", Value: configuration.ProfileTypeJira},
 					huh.Option[configuration.ProfileType]{Key: "GitHub", Value: configuration.ProfileTypeGithub},
+					huh.Option[configuration.ProfileType]{Key: "GitLab", Value: configuration.ProfileTypeGitlab},
 				).
 				Value(&profile.Type),
 		)).WithTheme(huh.ThemeDracula())
 }
 
-func (form EditProfile) getAccountForm(profile *configuration.Profile) *huh.Form {
+func (form EditProfile) getAccountForm(profile *configuration.Profile, maxSlugLength *string, branchTypes *string, maxResults *string) *huh.Form {
 	var steps []*huh.Group
 
 	switch profile.Type {
 	case configuration.ProfileTypeJira:
+		if profile.Jira.Auth == "" {
+			profile.Jira.Auth = configuration.JiraAuthBearer
+		}
+
 		steps = append(steps, huh.NewGroup(
 			huh.NewInput().
 				Title("Host").
@@ -78,9 +105,40 @@ func (form EditProfile) getAccountForm(profile *configuration.Profile) *huh.Form
 					return nil
 				}).
 				Value(&profile.Jira.Host),
+			huh.NewSelect[configuration.JiraAuth]().
+				Title("Authentication").
+				Options(
+					huh.Option[configuration.JiraAuth]{Key: "Personal access token (Jira Data Center)", Value: configuration.JiraAuthBearer},
+					huh.Option[configuration.JiraAuth]{Key: "Email and API token (Jira Cloud)", Value: configuration.JiraAuthBasic},
+					huh.Option[configuration.JiraAuth]{Key: "OAuth access token", Value: configuration.JiraAuthOAuth},
+				).
+				Value(&profile.Jira.Auth),
+		), huh.NewGroup(
+			huh.NewInput().
+				Title("Email").
+				Description("Email of your Atlassian account").
+				Validate(func(email string) error {
+					if !strings.Contains(email, "@") {
+						return fmt.Errorf("❌ %s (example: %s)", "Please enter a valid email", "me@example.com")
+					}
+					return nil
+				}).
+				Value(&profile.Jira.Email),
+		).WithHideFunc(func() bool {
+			return profile.Jira.Auth != configuration.JiraAuthBasic
+		}), huh.NewGroup(
 			huh.NewInput().
 				Title("Token").
-				Description("See https://support.atlassian.com/organization-administration/docs/understand-user-api-tokens/").
+				DescriptionFunc(func() string {
+					switch profile.Jira.Auth {
+					case configuration.JiraAuthBasic:
+						return "See https://support.atlassian.com/organization-administration/docs/understand-user-api-tokens/\n" + tokenStorageHint
+					case configuration.JiraAuthOAuth:
+						return "OAuth 2.0 access token, the host must be https://api.atlassian.com/ex/jira/<cloud-id> for Jira Cloud\n" + tokenStorageHint
+					default:
+						return "See https://confluence.atlassian.com/enterprise/using-personal-access-tokens-1026032365.html\n" + tokenStorageHint
+					}
+				}, &profile.Jira.Auth).
 				EchoMode(huh.EchoModePassword).
 				Value(&profile.Jira.Token),
 		), huh.NewGroup(
@@ -98,6 +156,12 @@ func (form EditProfile) getAccountForm(profile *configuration.Profile) *huh.Form
 				Title("JQL").
 				Description("Optional: Used to filter issues on 'dash' command").
 				Value(&profile.Jira.JQL),
+		), huh.NewGroup(
+			huh.NewInput().
+				Title("GitHub Token").
+				Description("Optional: Used to open pull requests with 'pr' command, see https://github.com/settings/tokens\n"+tokenStorageHint).
+				EchoMode(huh.EchoModePassword).
+				Value(&profile.Github.Token),
 		))
 	case configuration.ProfileTypeGithub:
 		steps = append(steps, huh.NewGroup(
@@ -106,13 +170,204 @@ func (form EditProfile) getAccountForm(profile *configuration.Profile) *huh.Form
 				Value(&profile.Github.User),
 			huh.NewInput().
 				Title("Token").
-				Description("See https://github.com/settings/tokens").
+				Description("See https://github.com/settings/tokens\n"+tokenStorageHint).
 				EchoMode(huh.EchoModePassword).
 				Value(&profile.Github.Token),
+		), huh.NewGroup(
+			huh.NewInput().
+				Title("Base URL").
+				Description("Optional: GitHub Enterprise API URL (e.g. https://github.example.com/api/v3/), keep empty for github.com").
+				Validate(func(url string) error {
+					re := regexp.MustCompile(`^https?://([\w-]+\.)+[\w-]{2,}(:\d+)?(/.*)?$`)
+					if url != "" && !re.MatchString(url) {
+						return fmt.Errorf("❌ %s (example: %s)", "The GitHub Enterprise URL must be a valid URL", "https://github.example.com/api/v3/")
+					}
+					return nil
+				}).
+				Value(&profile.Github.BaseURL),
+			huh.NewInput().
+				Title("Repository").
+				Description("Optional: owner/repo, keep empty to detect it from the Git remote origin").
+				Validate(func(s string) error {
+					if s != "" && len(strings.Split(s, "/")) != 2 {
+						return fmt.Errorf("❌ %s (example: %s)", "Please enter a valid repository", "ealenn/gira")
+					}
+					return nil
+				}).
+				Value(&profile.Github.Repository),
+		))
+	case configuration.ProfileTypeGitlab:
+		steps = append(steps, huh.NewGroup(
+			huh.NewInput().
+				Title("Host").
+				Validate(func(url string) error {
+					re := regexp.MustCompile(`^(https?://)?([\w-]+\.)+[\w-]{2,}(/.*)?$`)
+					if !re.MatchString(url) {
+						return fmt.Errorf("❌ %s (example: %s)", "The GitLab host must be a valid URL", "https://gitlab.com")
+					}
+					return nil
+				}).
+				Value(&profile.Gitlab.Host),
+			huh.NewInput().
+				Title("Token").
+				Description("See https://docs.gitlab.com/user/profile/personal_access_tokens/\n"+tokenStorageHint).
+				EchoMode(huh.EchoModePassword).
+				Value(&profile.Gitlab.Token),
+		), huh.NewGroup(
+			huh.NewInput().
+				Title("Project").
+				Description("Optional: Project path (e.g. group/project), keep empty to detect it from the Git remote origin").
+				Value(&profile.Gitlab.Project),
 		))
 	}
 
+	steps = append(steps, huh.NewGroup(
+		huh.NewInput().
+			Title("Branch template").
+			Description("Optional: Placeholders {type}, {key}, {slug}, {user} and {date} (default: {type}/{key}/{slug})").
+			Validate(func(s string) error {
+				if err := configuration.ValidateBranchTemplate(s); err != nil {
+					return fmt.Errorf("❌ %s (example: %s)", "Please enter a template with {key} and each placeholder once", "{type}/{key}-{slug}")
+				}
+				return nil
+			}).
+			Value(&profile.Branch.Template),
+		huh.NewInput().
+			Title("Branch pattern").
+			Description("Optional: Regex with a (?P<key>...) group to find the issue in branch names, keep empty to use the template").
+			Validate(func(s string) error {
+				if s == "" {
+					return nil
+				}
+				pattern, err := regexp.Compile(s)
+				if err != nil || pattern.SubexpIndex("key") < 0 {
+					return fmt.Errorf("❌ %s (example: %s)", "Please enter a valid regex with a key group", `^(?P<key>[A-Z]+-\d+)-(?P<slug>.+)$`)
+				}
+				return nil
+			}).
+			Value(&profile.Branch.Pattern),
+		huh.NewInput().
+			Title("Branch max slug length").
+			Description("Optional: Truncate the slugified issue title, keep empty for no limit").
+			Validate(func(s string) error {
+				if length, err := strconv.Atoi(s); s != "" && (err != nil || length < 0) {
+					return fmt.Errorf("❌ %s (example: %s)", "Please enter a valid length", "40")
+				}
+				return nil
+			}).
+			Value(maxSlugLength),
+		huh.NewInput().
+			Title("Branch types").
+			Description("Optional: Issue types or labels to branch types, comma separated (e.g. bug=bugfix, incident=hotfix, documentation=docs)").
+			Validate(func(s string) error {
+				if _, err := parseBranchTypes(s); err != nil {
+					return fmt.Errorf("❌ %s (example: %s)", "Please enter valid branch types", "bug=bugfix, incident=hotfix")
+				}
+				return nil
+			}).
+			Value(branchTypes),
+		huh.NewInput().
+			Title("Default branch type").
+			Description("Optional: Used when no issue type matches (default: feature)").
+			Value(&profile.Branch.DefaultType),
+	), huh.NewGroup(
+		huh.NewInput().
+			Title("Commit template").
+			Description("Optional: Used by 'commit' command, placeholders {type}, {key} and {summary} (default: {type}({key}): {summary})").
+			Value(&profile.Commit.Template),
+		huh.NewInput().
+			Title("Dashboard max issues").
+			Description("Optional: Maximum number of issues loaded by 'dash' and 'list' commands (default: 500)").
+			Validate(func(s string) error {
+				if length, err := strconv.Atoi(s); s != "" && (err != nil || length < 1) {
+					return fmt.Errorf("❌ %s (example: %s)", "Please enter a valid number", "1000")
+				}
+				return nil
+			}).
+			Value(maxResults),
+		huh.NewMultiSelect[string]().
+			Title("Dashboard columns").
+			Description("Optional: Columns shown in 'dash' command after ID, title and status").
+			Options(
+				huh.NewOption("Assignee", configuration.DashColumnAssignee),
+				huh.NewOption("Types", configuration.DashColumnTypes),
+				huh.NewOption("Created date", configuration.DashColumnCreated),
+				huh.NewOption("Priority (Jira)", configuration.DashColumnPriority),
+			).
+			Value(&profile.Dash.Columns),
+	), huh.NewGroup(
+		huh.NewInput().
+			Title("CA bundle").
+			Description("Optional: PEM file added to the system certificates to verify self-hosted servers").
+			Validate(validateFile).
+			Value(&profile.TLS.CABundle),
+		huh.NewInput().
+			Title("Client certificate").
+			Description("Optional: PEM client certificate for mutual TLS").
+			Validate(validateFile).
+			Value(&profile.TLS.ClientCertificate),
+		huh.NewInput().
+			Title("Client key").
+			Description("Optional: PEM private key of the client certificate").
+			Validate(func(s string) error {
+				if (s == "") != (profile.TLS.ClientCertificate == "") {
+					return fmt.Errorf("❌ %s", "The client certificate and key must be set together")
+				}
+				return validateFile(s)
+			}).
+			Value(&profile.TLS.ClientKey),
+		huh.NewConfirm().
+			Title("Skip TLS certificate verification?").
+			Description("Not recommended: connections are not secure, prefer a CA bundle").
+			Value(&profile.TLS.Insecure),
+	))
+
 	return huh.NewForm(
 		steps...,
 	).WithTheme(huh.ThemeDracula())
 }
+
+// parseBranchTypes reads "issueType=branchType" pairs separated by commas
+func parseBranchTypes(value string) (map[string]string, error) {
+	branchTypes := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		issueType, branchType, found := strings.Cut(pair, "=")
+		issueType = strings.TrimSpace(issueType)
+		branchType = strings.TrimSpace(branchType)
+		if !found || issueType == "" || branchType == "" {
+			return nil, fmt.Errorf("invalid branch type %s", pair)
+		}
+
+		branchTypes[strings.ToLower(issueType)] = branchType
+	}
+
+	if len(branchTypes) == 0 {
+		return nil, nil
+	}
+
+	return branchTypes, nil
+}
+
+func formatBranchTypes(branchTypes map[string]string) string {
+	pairs := []string{}
+	for issueType, branchType := range branchTypes {
+		pairs = append(pairs, issueType+"="+branchType)
+	}
+	slices.Sort(pairs)
+
+	return strings.Join(pairs, ", ")
+}
+
+func validateFile(path string) error {
+	if path == "" {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("❌ %s (%s)", "File not found", path)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package forms
+
+import (
+	"github.com/charmbracelet/huh"
+
+	"github.com/Ealenn/gira/internal/log"
+)
+
+type BulkAction string
+
+const (
+	BulkActionAssign     BulkAction = "ASSIGN"
+	BulkActionTransition BulkAction = "TRANSITION"
+	BulkActionLabel      BulkAction = "LABEL"
+	BulkActionOpen       BulkAction = "OPEN"
+)
+
+type SelectBulkActionResult struct {
+	Action BulkAction
+}
+
+type SelectBulkAction struct {
+	logger *log.Logger
+	ui     *huh.Form
+	Result *SelectBulkActionResult
+}
+
+func NewSelectBulkAction(logger *log.Logger) *SelectBulkAction {
+	return &SelectBulkAction{
+		logger,
+		nil,
+		&SelectBulkActionResult{},
+	}
+}
+
+func (form SelectBulkAction) Ask(title string, description string) *SelectBulkActionResult {
+	form.ui = form.getForm(title, description)
+	err := form.ui.Run()
+
+	if err != nil {
+		form.logger.Fatal("❌ The operation was %s", "canceled")
+	}
+
+	form.ui.View()
+	return form.Result
+}
+
+func (form SelectBulkAction) getForm(title string, description string) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[BulkAction]().
+				Title(title).
+				Description(description).
+				Options(
+					huh.NewOption("👤 Assign to me", BulkActionAssign),
+					huh.NewOption("🔀 Move to status", BulkActionTransition),
+					huh.NewOption("🏷️ Add label", BulkActionLabel),
+					huh.NewOption("🌎 Open in browser", BulkActionOpen),
+				).
+				Value(&form.Result.Action),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
@@ -0,0 +1,60 @@
+package forms
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/Ealenn/gira/internal/log"
+)
+
+type SelectCommitResult struct {
+	Message string
+}
+
+type SelectCommit struct {
+	logger *log.Logger
+	ui     *huh.Form
+	Result *SelectCommitResult
+}
+
+func NewSelectCommit(logger *log.Logger) *SelectCommit {
+	return &SelectCommit{
+		logger,
+		nil,
+		&SelectCommitResult{},
+	}
+}
+
+func (form SelectCommit) Ask(title string, description string, messages ...string) *SelectCommitResult {
+	form.ui = form.getForm(title, description, messages)
+	err := form.ui.Run()
+
+	if err != nil {
+		form.logger.Fatal("❌ The operation was %s", "canceled")
+	}
+
+	form.ui.View()
+	return form.Result
+}
+
+func (form SelectCommit) getForm(title string, description string, options []string) *huh.Form {
+	var opts []huh.Option[string]
+
+	for _, opt := range options {
+		// Keep footers on the line of the option
+		opts = append(opts, huh.NewOption(strings.ReplaceAll(opt, "\n\n", " · "), opt))
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(title).
+				Description(description).
+				Options(
+					opts...,
+				).
+				Value(&form.Result.Message),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
@@ -0,0 +1,64 @@
+package forms
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/Ealenn/gira/internal/issue"
+	"github.com/Ealenn/gira/internal/log"
+)
+
+type SelectTransitionResult struct {
+	Transition issue.Transition
+}
+
+type SelectTransition struct {
+	logger *log.Logger
+	ui     *huh.Form
+	Result *SelectTransitionResult
+}
+
+func NewSelectTransition(logger *log.Logger) *SelectTransition {
+	return &SelectTransition{
+		logger,
+		nil,
+		&SelectTransitionResult{},
+	}
+}
+
+func (form SelectTransition) Ask(title string, description string, transitions ...issue.Transition) *SelectTransitionResult {
+	form.ui = form.getForm(title, description, transitions)
+	err := form.ui.Run()
+
+	if err != nil {
+		form.logger.Fatal("❌ The operation was %s", "canceled")
+	}
+
+	form.ui.View()
+	return form.Result
+}
+
+func (form SelectTransition) getForm(title string, description string, options []issue.Transition) *huh.Form {
+	var opts []huh.Option[issue.Transition]
+
+	for _, opt := range options {
+		label := opt.Name
+		if opt.Status != "" && opt.Status != opt.Name {
+			label = fmt.Sprintf("%s → %s", opt.Name, opt.Status)
+		}
+		opts = append(opts, huh.NewOption(label, opt))
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[issue.Transition]().
+				Title(title).
+				Description(description).
+				Options(
+					opts...,
+				).
+				Value(&form.Result.Transition),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Ealenn/gira/internal/git"
 	"github.com/Ealenn/gira/internal/issue"
 	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/output"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -80,6 +81,9 @@ func (cmd *Issue) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "b":
 			cmd.action = "branch"
 			return cmd, tea.Quit
+		case "c":
+			cmd.action = "comment"
+			return cmd, tea.Quit
 		}
 	}
 
@@ -143,7 +147,7 @@ func (cmd *Issue) View() string {
 	rightBox := contentStyle.Render(cmd.componentContent.View())
 	mainContent := lipgloss.JoinHorizontal(lipgloss.Top, leftBox, rightBox)
 
-	helpBar := helpStyle.Render("ESC/Q Quit | ↑/↓ Scroll | a Assign | b Branch | o Open")
+	helpBar := helpStyle.Render("ESC/Q Quit | ↑/↓ Scroll | a Assign | b Branch | c Comment | o Open")
 	return lipgloss.JoinVertical(lipgloss.Left, mainContent, helpBar)
 }
 
@@ -164,6 +168,12 @@ func (cmd *Issue) RunWithIssue(issue *issue.Issue, enableAI bool) {
 		}
 	}
 
+	comments, err := cmd.tracker.GetComments(issue.ID)
+	if err != nil {
+		cmd.logger.Debug("Unable to fetch comments of %s: %v", issue.ID, err)
+	}
+	cmd.componentContentValue += cmd.renderComments(comments)
+
 	cmd.componentAttributesValue = fmt.Sprintf("> #%s\n\nStatus: %s\n\n", issue.ID, issue.Status)
 	cmd.componentAttributesValue += "\n> Types \n\n"
 	for _, tag := range issue.Types {
@@ -187,23 +197,40 @@ func (cmd *Issue) RunWithIssue(issue *issue.Issue, enableAI bool) {
 
 	switch cmd.action {
 	case "open":
-		NewOpen(cmd.logger, cmd.branch, cmd.tracker).Run(&issue.ID)
+		if err := NewOpen(cmd.logger, cmd.branch, cmd.tracker).OpenIssue(issue); err != nil {
+			fatalError(cmd.logger, err, "❌ Unable to open issue %s", issue.ID)
+		}
 	case "assign":
-		cmd.tracker.SelfAssignIssue(issue.ID)
+		if err := cmd.tracker.SelfAssignIssue(issue.ID); err != nil {
+			fatalError(cmd.logger, err, "❌ Unable to assign issue %s", issue.ID)
+		}
+		NewIssue(cmd.logger, cmd.tracker, cmd.git, cmd.branch).RunWithIssue(issue, enableAI)
+	case "comment":
+		NewComment(cmd.logger, cmd.branch, cmd.tracker).Post(issue.ID, "")
 		NewIssue(cmd.logger, cmd.tracker, cmd.git, cmd.branch).RunWithIssue(issue, enableAI)
 	case "branch":
 		NewBranch(cmd.logger, cmd.tracker, cmd.git, cmd.branch).Run(issue.ID, false, enableAI, false)
 	}
 }
 
-func (cmd *Issue) Run(optionalIssueID *string, enableAI bool) {
+func (cmd *Issue) Run(optionalIssueID *string, enableAI bool, format output.Format) {
 	var issueID string
 	if optionalIssueID != nil {
 		issueID = *optionalIssueID
 	} else {
 		issueID = cmd.branch.GetCurrentBranch().IssueID
 	}
-	issue := cmd.tracker.GetIssue(issueID)
+	issue, err := cmd.tracker.GetIssue(issueID)
+	if err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to find issue %s", issueID)
+	}
+
+	if format != "" {
+		if err := output.NewPrinter(format).Issue(issue); err != nil {
+			cmd.logger.Fatal("❌ Unable to print issue %s: %v", issueID, err)
+		}
+		return
+	}
 
 	cmd.RunWithIssue(issue, enableAI)
 }
@@ -224,6 +251,19 @@ func (cmd *Issue) renderComponents() {
 	cmd.View()
 }
 
+func (cmd *Issue) renderComments(comments []issue.Comment) string {
+	if len(comments) == 0 {
+		return ""
+	}
+
+	thread := fmt.Sprintf("\n\r\n\r---\n\r\n\r## 💬 Comments (%d)\n\r\n\r", len(comments))
+	for _, comment := range comments {
+		thread += fmt.Sprintf("> **%s** · %s\n\r\n\r%s\n\r\n\r", comment.Author, comment.CreatedAt.Format(time.RFC822), comment.Body)
+	}
+
+	return thread
+}
+
 func (cmd *Issue) renderMarkdown(markdown string, wrap int) string {
 	renderer, _ := glamour.NewTermRenderer(
 		glamour.WithAutoStyle(),
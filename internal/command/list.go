@@ -0,0 +1,54 @@
+package command
+
+import (
+	"github.com/Ealenn/gira/internal/configuration"
+	"github.com/Ealenn/gira/internal/issue"
+	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/output"
+)
+
+type List struct {
+	logger  *log.Logger
+	profile *configuration.Profile
+	tracker issue.Tracker
+}
+
+func NewList(logger *log.Logger, profile *configuration.Profile, tracker issue.Tracker) *List {
+	return &List{
+		logger,
+		profile,
+		tracker,
+	}
+}
+
+func (cmd List) Run(options issue.SearchOptions, format output.Format) {
+	if format == "" {
+		format = output.FormatTable
+	}
+
+	checkBoard(cmd.logger, cmd.profile)
+
+	// Pages keep the order of the tracker when a sort is requested
+	sortedIssues := []*issue.Issue{}
+	issues, err := cmd.tracker.SearchIssues(options, func(pageIssues []*issue.Issue) {
+		sortedIssues = append(sortedIssues, pageIssues...)
+	})
+	if err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to search issues")
+	}
+
+	if options.Sort == "" {
+		sortedIssues = output.SortIssues(issues)
+	}
+
+	if err := output.NewPrinter(format).Issues(sortedIssues); err != nil {
+		cmd.logger.Fatal("❌ Unable to print issues: %v", err)
+	}
+}
+
+// checkBoard stops the dashboard and the list when no Jira board is configured to search issues
+func checkBoard(logger *log.Logger, profile *configuration.Profile) {
+	if profile.Type == configuration.ProfileTypeJira && profile.Jira.Board == "" {
+		logger.Fatal("❌ %s\nYou can configure new dashboard with %s", "No dashboard configured", "gira config -p "+profile.Name)
+	}
+}
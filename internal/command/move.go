@@ -0,0 +1,73 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/Ealenn/gira/internal/branch"
+	"github.com/Ealenn/gira/internal/command/forms"
+	"github.com/Ealenn/gira/internal/issue"
+	"github.com/Ealenn/gira/internal/log"
+)
+
+type Move struct {
+	logger  *log.Logger
+	branch  *branch.Manager
+	tracker issue.Tracker
+}
+
+func NewMove(logger *log.Logger, branch *branch.Manager, tracker issue.Tracker) *Move {
+	return &Move{
+		logger,
+		branch,
+		tracker,
+	}
+}
+
+func (cmd Move) Run(optionalIssueID *string, optionalStatus *string) {
+	var issueID string
+	if optionalIssueID != nil {
+		issueID = *optionalIssueID
+	} else {
+		issueID = cmd.branch.GetCurrentBranch().IssueID
+	}
+
+	currentIssue, err := cmd.tracker.GetIssue(issueID)
+	if err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to find issue %s", issueID)
+	}
+
+	transitions, err := cmd.tracker.GetTransitions(currentIssue.ID)
+	if err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to fetch transitions of issue %s", currentIssue.ID)
+	}
+
+	if len(transitions) == 0 {
+		cmd.logger.Fatal("❌ No transition available for issue %s (%s)", currentIssue.ID, currentIssue.Status)
+	}
+
+	var transition *issue.Transition
+	if optionalStatus != nil {
+		transition = cmd.findTransition(transitions, *optionalStatus)
+		if transition == nil {
+			cmd.logger.Fatal("❌ Status %s is not reachable from %s for issue %s", *optionalStatus, currentIssue.Status, currentIssue.ID)
+		}
+	} else {
+		transition = &forms.NewSelectTransition(cmd.logger).Ask("🚚 Move issue to", currentIssue.ID+" : "+currentIssue.Title+" ("+currentIssue.Status+")", transitions...).Transition
+	}
+
+	if err := cmd.tracker.TransitionIssue(currentIssue.ID, *transition); err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to move issue %s to %s", currentIssue.ID, transition.Status)
+	}
+
+	cmd.logger.Info("✅ %s moved from %s to %s", currentIssue.ID, currentIssue.Status, transition.Status)
+}
+
+func (cmd Move) findTransition(transitions []issue.Transition, status string) *issue.Transition {
+	for index, transition := range transitions {
+		if strings.EqualFold(transition.Name, status) || strings.EqualFold(transition.Status, status) || transition.ID == status {
+			return &transitions[index]
+		}
+	}
+
+	return nil
+}
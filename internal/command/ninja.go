@@ -32,7 +32,9 @@ func NewNinja(logger *log.Logger, profile *configuration.Profile, tracker issue.
 
 func (cmd Ninja) Run(enableAI bool, force bool) {
 	agent := ai.NewOpenAI(cmd.logger)
-	options := forms.NewCreateIssue(cmd.logger).Ask(cmd.profile.Type == configuration.ProfileTypeJira)
+	form := forms.NewCreateIssue(cmd.logger)
+	form.Result.Project = cmd.profile.Jira.Project
+	options := form.Ask(cmd.profile.Type == configuration.ProfileTypeJira)
 
 	if enableAI {
 		titleSuggestion, titleSuggestionErr := agent.IssueRewrite("Issue creation, this is the Title of the new issue", options.Title)
@@ -60,12 +62,15 @@ func (cmd Ninja) Run(enableAI bool, force bool) {
 		}
 	}
 
-	issue := cmd.tracker.CreateIssue(issue.CreateIssueOptions{
+	issue, err := cmd.tracker.CreateIssue(issue.CreateIssueOptions{
 		Type:        options.Type,
 		Project:     options.Project,
 		Title:       options.Title,
 		Description: options.Description,
 	})
+	if err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to create issue %s", options.Title)
+	}
 	cmd.logger.Info("Issue %s created, see %s", issue.ID, issue.URL)
 
 	NewBranch(cmd.logger, cmd.tracker, cmd.git, cmd.branch).RunWithIssue(issue, true, enableAI, force)
@@ -22,8 +22,6 @@ func NewOpen(logger *log.Logger, branch *branch.Manager, tracker issue.Tracker)
 }
 
 func (cmd Open) Run(optionalIssueID *string) {
-	browser := browser.NewBrowser(cmd.logger)
-
 	var issueID string
 	if optionalIssueID != nil {
 		issueID = *optionalIssueID
@@ -31,7 +29,18 @@ func (cmd Open) Run(optionalIssueID *string) {
 		issueID = cmd.branch.GetCurrentBranch().IssueID
 	}
 
-	issue := cmd.tracker.GetIssue(issueID)
+	issue, err := cmd.tracker.GetIssue(issueID)
+	if err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to find issue %s", issueID)
+	}
+
 	cmd.logger.Info("🌎 Open issue %s : %s", issue.ID, issue.Title)
-	browser.Open(issue.URL)
+	if err := cmd.OpenIssue(issue); err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to open issue %s", issue.ID)
+	}
+}
+
+// OpenIssue opens the issue in the browser without exiting on failure, to be used from TUIs
+func (cmd Open) OpenIssue(issue *issue.Issue) error {
+	return browser.NewBrowser(cmd.logger).Open(issue.URL)
 }
@@ -0,0 +1,127 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/Ealenn/gira/internal/ai"
+	"github.com/Ealenn/gira/internal/branch"
+	"github.com/Ealenn/gira/internal/command/forms"
+	"github.com/Ealenn/gira/internal/configuration"
+	"github.com/Ealenn/gira/internal/git"
+	"github.com/Ealenn/gira/internal/issue"
+	"github.com/Ealenn/gira/internal/log"
+)
+
+type PullRequest struct {
+	logger  *log.Logger
+	profile *configuration.Profile
+	tracker issue.Tracker
+	git     *git.Git
+	branch  *branch.Manager
+}
+
+func NewPullRequest(logger *log.Logger, profile *configuration.Profile, tracker issue.Tracker, git *git.Git, branch *branch.Manager) *PullRequest {
+	return &PullRequest{
+		logger,
+		profile,
+		tracker,
+		git,
+		branch,
+	}
+}
+
+func (cmd PullRequest) Run(base string, draft bool, enableAI bool, force bool) {
+	repository := cmd.getRepository()
+
+	currentBranch, err := cmd.git.CurrentBranch()
+	if err != nil {
+		cmd.logger.Fatal("❌ Unable to check current branch")
+	}
+
+	branchIssue := cmd.branch.GetCurrentBranch()
+	currentIssue, err := cmd.tracker.GetIssue(branchIssue.IssueID)
+	if err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to find issue %s", branchIssue.IssueID)
+	}
+
+	if base == "" {
+		base = cmd.profile.PullRequest.Base
+	}
+	if base == "" {
+		base, err = repository.GetDefaultBranch()
+		if err != nil {
+			fatalError(cmd.logger, err, "❌ Unable to find the default branch of the repository")
+		}
+	}
+
+	options := issue.PullRequestOptions{
+		Title: currentIssue.Title,
+		Body:  cmd.getReference(currentIssue),
+		Head:  currentBranch,
+		Base:  base,
+		Draft: draft || cmd.profile.PullRequest.Draft,
+	}
+
+	if enableAI {
+		commits, _ := cmd.git.Log("origin/" + base)
+		diff, _ := cmd.git.Diff("origin/" + base)
+
+		agent := ai.NewOpenAI(cmd.logger)
+		description, err := agent.PullRequestDescription(currentIssue, commits, diff)
+		if err == nil && description != "" {
+			options.Body = fmt.Sprintf("%s\n\n%s", description, options.Body)
+		}
+	}
+
+	if !force {
+		if !forms.NewConfirm(cmd.logger).Ask(
+			fmt.Sprintf("🚀 Open pull request %s → %s?", options.Head, options.Base),
+			fmt.Sprintf("Title: %s\nDraft: %t\n\n%s", options.Title, options.Draft, options.Body),
+			forms.TypeConfirm,
+		).Confirmed {
+			cmd.logger.Fatal("❌ The operation was %s", "canceled")
+		}
+	}
+
+	if output, err := cmd.git.Push(currentBranch); err != nil {
+		cmd.logger.Fatal("%s", output)
+	}
+	cmd.logger.Info("✅ %s pushed to origin", currentBranch)
+
+	pullRequest, err := repository.CreatePullRequest(options)
+	if err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to create pull request for %s", currentBranch)
+	}
+
+	cmd.logger.Info("✅ Pull request #%s created, see %s", pullRequest.ID, pullRequest.URL)
+}
+
+// getRepository reuses the GitHub tracker, or builds one from the profile GitHub settings for other trackers
+func (cmd PullRequest) getRepository() issue.PullRequestCreator {
+	if repository, ok := issue.Unwrap(cmd.tracker).(issue.PullRequestCreator); ok {
+		return repository
+	}
+
+	if cmd.profile.Type == configuration.ProfileTypeJira {
+		if err := cmd.profile.ResolveSecret("github"); err != nil {
+			cmd.logger.Warn("⚠️ %s", err.Error())
+		}
+
+		// The TLS settings are those of the Jira server, GitHub uses the system certificates
+		githubProfile := *cmd.profile
+		githubProfile.TLS = configuration.TLS{}
+		return issue.NewGitHub(cmd.logger, &githubProfile, cmd.git)
+	}
+
+	cmd.logger.Fatal("❌ Pull requests are not supported for %s profiles", string(cmd.profile.Type))
+	return nil
+}
+
+// getReference links the pull request to the issue, GitHub closes the issue on merge
+func (cmd PullRequest) getReference(currentIssue *issue.Issue) string {
+	if cmd.profile.Type == configuration.ProfileTypeGithub {
+		return fmt.Sprintf("Closes #%s", currentIssue.ID)
+	}
+
+	return fmt.Sprintf("Issue: [%s](%s)", currentIssue.ID, currentIssue.URL)
+}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ealenn/gira/internal/configuration"
+	"github.com/Ealenn/gira/internal/issue"
+	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/output"
+)
+
+var relativeSince = regexp.MustCompile(`^(\d+)([hdw])$`)
+
+type Search struct {
+	logger  *log.Logger
+	profile *configuration.Profile
+	tracker issue.Tracker
+}
+
+func NewSearch(logger *log.Logger, profile *configuration.Profile, tracker issue.Tracker) *Search {
+	return &Search{
+		logger,
+		profile,
+		tracker,
+	}
+}
+
+func (cmd Search) Run(options issue.SearchOptions, updatedSince string, format output.Format) {
+	if updatedSince != "" {
+		since, err := cmd.parseSince(updatedSince)
+		if err != nil {
+			cmd.logger.Fatal("❌ Invalid updated since %s (example: %s or %s)", updatedSince, "7d", "2025-01-31")
+		}
+		options.UpdatedSince = since
+		options.UpdatedSinceValue = updatedSince
+	}
+
+	if options.Sort != "" && options.Sort != "created" && options.Sort != "updated" {
+		cmd.logger.Fatal("❌ Invalid sort %s (expected %s or %s)", options.Sort, "created", "updated")
+	}
+	if options.Order != "" && options.Order != "asc" && options.Order != "desc" {
+		cmd.logger.Fatal("❌ Invalid order %s (expected %s or %s)", options.Order, "asc", "desc")
+	}
+
+	NewList(cmd.logger, cmd.profile, cmd.tracker).Run(options, format)
+}
+
+// parseSince reads a date (2025-01-31) or a relative duration in hours, days or weeks (12h, 7d, 2w)
+func (cmd Search) parseSince(value string) (time.Time, error) {
+	if matches := relativeSince.FindStringSubmatch(strings.ToLower(value)); matches != nil {
+		amount, _ := strconv.Atoi(matches[1])
+		switch matches[2] {
+		case "h":
+			return time.Now().Add(-time.Duration(amount) * time.Hour), nil
+		case "d":
+			return time.Now().AddDate(0, 0, -amount), nil
+		case "w":
+			return time.Now().AddDate(0, 0, -7*amount), nil
+		}
+	}
+
+	since, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %s: %w", value, err)
+	}
+
+	return since, nil
+}
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Ealenn/gira/internal/branch"
+	"github.com/Ealenn/gira/internal/configuration"
+	"github.com/Ealenn/gira/internal/issue"
+	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/timer"
+)
+
+type Time struct {
+	logger  *log.Logger
+	profile *configuration.Profile
+	branch  *branch.Manager
+	tracker issue.Tracker
+	timers  *timer.Store
+}
+
+func NewTime(logger *log.Logger, profile *configuration.Profile, branch *branch.Manager, tracker issue.Tracker) *Time {
+	return &Time{
+		logger,
+		profile,
+		branch,
+		tracker,
+		timer.NewStore(logger),
+	}
+}
+
+func (cmd Time) Start(optionalIssueID *string) {
+	issueID := cmd.getIssueID(optionalIssueID)
+	if _, err := cmd.tracker.GetIssue(issueID); err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to find issue %s", issueID)
+	}
+
+	startedTimer, err := cmd.timers.Start(cmd.profile.Name, issue.GetScope(cmd.tracker), issueID)
+	if errors.Is(err, timer.ErrAlreadyRunning) {
+		cmd.logger.Fatal("⏱️ Timer already running on %s for %s", issueID, issue.FormatDuration(time.Since(startedTimer.StartedAt)))
+	}
+	if err != nil {
+		cmd.logger.Debug("%v", err)
+		cmd.logger.Fatal("❌ Unable to start timer on %s", issueID)
+	}
+
+	cmd.logger.Info("⏱️ Timer started on %s", issueID)
+}
+
+func (cmd Time) Stop(optionalIssueID *string, comment string) {
+	issueID := cmd.getIssueID(optionalIssueID)
+
+	runningTimer := cmd.findTimer(issueID)
+	if runningTimer == nil {
+		cmd.logger.Fatal("❌ No timer running on %s, start one with %s", issueID, "gira time start")
+	}
+
+	spent := time.Since(runningTimer.StartedAt)
+	if spent < time.Minute {
+		cmd.logger.Warn("⚠️  Less than a minute on %s, %s is logged", issueID, "1m")
+		spent = time.Minute
+	}
+
+	// The timer keeps running if the worklog can't be saved
+	cmd.addWorklog(issueID, runningTimer.StartedAt, spent, comment)
+
+	if _, err := cmd.timers.Stop(cmd.profile.Name, issue.GetScope(cmd.tracker), issueID); err != nil {
+		cmd.logger.Debug("%v", err)
+		cmd.logger.Fatal("❌ Unable to stop timer on %s", issueID)
+	}
+}
+
+func (cmd Time) Status() {
+	timers, err := cmd.timers.List()
+	if err != nil {
+		cmd.logger.Debug("%v", err)
+		cmd.logger.Fatal("❌ Unable to read %s", "timers")
+	}
+
+	if len(timers) == 0 {
+		cmd.logger.Info("⏱️ No timer running")
+		return
+	}
+
+	for _, runningTimer := range timers {
+		cmd.logger.Info("⏱️ %s running for %s (profile %s, since %s)",
+			runningTimer.IssueID,
+			issue.FormatDuration(time.Since(runningTimer.StartedAt)),
+			runningTimer.Profile,
+			runningTimer.StartedAt.Format(time.Kitchen),
+		)
+	}
+}
+
+// Log adds a worklog without timer, duration is written like 1h30m or "1h 30m"
+func (cmd Time) Log(optionalIssueID *string, duration string, comment string) {
+	issueID := cmd.getIssueID(optionalIssueID)
+
+	spent, err := time.ParseDuration(strings.ReplaceAll(duration, " ", ""))
+	if err != nil || spent < time.Minute {
+		cmd.logger.Fatal("❌ Invalid duration %s (example: %s)", duration, "1h30m")
+	}
+
+	cmd.addWorklog(issueID, time.Now().Add(-spent), spent, comment)
+}
+
+func (cmd Time) addWorklog(issueID string, started time.Time, spent time.Duration, comment string) {
+	if err := cmd.tracker.AddWorklog(issueID, started, spent, comment); err != nil {
+		fatalError(cmd.logger, err, "❌ Unable to log time on %s", issueID)
+	}
+
+	cmd.logger.Info("✅ %s logged on %s", issue.FormatDuration(spent), issueID)
+}
+
+func (cmd Time) findTimer(issueID string) *timer.Timer {
+	timers, err := cmd.timers.List()
+	if err != nil {
+		cmd.logger.Debug("%v", err)
+		cmd.logger.Fatal("❌ Unable to read %s", "timers")
+	}
+
+	if index := cmd.timers.IndexOf(timers, cmd.profile.Name, issue.GetScope(cmd.tracker), issueID); index >= 0 {
+		return &timers[index]
+	}
+
+	return nil
+}
+
+func (cmd Time) getIssueID(optionalIssueID *string) string {
+	if optionalIssueID != nil {
+		return *optionalIssueID
+	}
+
+	return cmd.branch.GetCurrentBranch().IssueID
+}
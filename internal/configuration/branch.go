@@ -0,0 +1,27 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BranchPlaceholders are replaced in branch templates by the issue and Git settings
+var BranchPlaceholders = []string{"{type}", "{key}", "{slug}", "{user}", "{date}"}
+
+// ValidateBranchTemplate checks that the issue can be found back in the branch names, the template needs {key} and each placeholder once
+func ValidateBranchTemplate(template string) error {
+	if template == "" {
+		return nil
+	}
+
+	if !strings.Contains(template, "{key}") {
+		return fmt.Errorf("branch template %s has no {key} placeholder", template)
+	}
+	for _, placeholder := range BranchPlaceholders {
+		if strings.Count(template, placeholder) > 1 {
+			return fmt.Errorf("branch template %s repeats the %s placeholder", template, placeholder)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package configuration
+
+import "testing"
+
+func TestValidateBranchTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		valid    bool
+	}{
+		{"", true},
+		{"{type}/{key}/{slug}", true},
+		{"{user}/{date}-{key}", true},
+		{"{key}", true},
+		{"{type}/{slug}", false},
+		{"{type}/{key}/{key}", false},
+		{"{key}/{slug}-{slug}", false},
+		{"{type}/{type}/{key}", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.template, func(t *testing.T) {
+			if err := ValidateBranchTemplate(test.template); (err == nil) != test.valid {
+				t.Errorf("ValidateBranchTemplate(%q) = %v, want valid %t", test.template, err, test.valid)
+			}
+		})
+	}
+}
@@ -1,12 +1,11 @@
 package configuration
 
 import (
-	"encoding/json"
-	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/Ealenn/gira/internal/log"
@@ -16,6 +15,7 @@ type Configuration struct {
 	logger *log.Logger
 	JSON   JSONConfiguration
 	Path   string
+	Err    error // Error reading the configuration file, profiles are empty when set
 }
 
 func New(logger *log.Logger) *Configuration {
@@ -28,10 +28,7 @@ func New(logger *log.Logger) *Configuration {
 	var jsonConfiguration JSONConfiguration
 
 	if _, statError := os.Stat(configurationFilePath); statError != nil {
-		fileContent, createConfigurationError := updateConfiguration(configurationFilePath, JSONConfiguration{
-			Profiles:         []Profile{},
-			LastVersionCheck: 0,
-		})
+		fileContent, createConfigurationError := updateConfiguration(configurationFilePath, func(*JSONConfiguration) {})
 
 		if createConfigurationError != nil {
 			logger.Fatal("unable to create configuration due to %v", createConfigurationError)
@@ -39,9 +36,14 @@ func New(logger *log.Logger) *Configuration {
 
 		jsonConfiguration = *fileContent
 	} else {
-		fileContent, readConfigurationError := readConfiguration(configurationFilePath)
+		fileContent, _, readConfigurationError := readConfiguration(configurationFilePath)
 		if readConfigurationError != nil {
-			logger.Fatal("⚠️  %s\nPlease run the %s command or change your configuration here %s", "Configuration is invalid", "gira config", configurationFilePath)
+			logger.Debug("Unable to read configuration %v", readConfigurationError)
+			return &Configuration{
+				logger: logger,
+				Path:   configurationFilePath,
+				Err:    readConfigurationError,
+			}
 		}
 
 		jsonConfiguration = *fileContent
@@ -55,38 +57,51 @@ func New(logger *log.Logger) *Configuration {
 }
 
 func (configuration *Configuration) RemoveProfile(profile Profile) error {
-	for index, jsonProfile := range configuration.JSON.Profiles {
-		if jsonProfile.Name == profile.Name {
-			configuration.JSON.Profiles = slices.Delete(configuration.JSON.Profiles, index, index+1)
-			break
+	return configuration.update(func(jsonConfiguration *JSONConfiguration) {
+		for index, jsonProfile := range jsonConfiguration.Profiles {
+			if jsonProfile.Name == profile.Name {
+				configuration.deleteSecrets(jsonProfile)
+				jsonConfiguration.Profiles = slices.Delete(jsonConfiguration.Profiles, index, index+1)
+				break
+			}
 		}
-	}
-
-	_, err := updateConfiguration(configuration.Path, configuration.JSON)
-	return err
+	})
 }
 
 func (configuration *Configuration) AddProfile(newProfile Profile) error {
-	found := false
-	for index, jsonProfile := range configuration.JSON.Profiles {
-		if jsonProfile.Name == newProfile.Name {
-			configuration.JSON.Profiles[index] = newProfile
-			found = true
-			break
+	configuration.storeSecrets(&newProfile)
+
+	return configuration.update(func(jsonConfiguration *JSONConfiguration) {
+		for index, jsonProfile := range jsonConfiguration.Profiles {
+			if jsonProfile.Name == newProfile.Name {
+				jsonConfiguration.Profiles[index] = newProfile
+				return
+			}
 		}
-	}
 
-	if !found {
-		configuration.JSON.Profiles = append(configuration.JSON.Profiles, newProfile)
-	}
+		jsonConfiguration.Profiles = append(jsonConfiguration.Profiles, newProfile)
+	})
+}
 
-	_, err := updateConfiguration(configuration.Path, configuration.JSON)
-	return err
+// SetRemote maps a Git remote (host, host/owner or host/owner/repository) to a profile, an empty profile name removes the mapping
+func (configuration *Configuration) SetRemote(remote string, profileName string) error {
+	remote = strings.Trim(remote, "/")
+	return configuration.update(func(jsonConfiguration *JSONConfiguration) {
+		if profileName == "" {
+			delete(jsonConfiguration.Remotes, remote)
+			return
+		}
+		if jsonConfiguration.Remotes == nil {
+			jsonConfiguration.Remotes = map[string]string{}
+		}
+		jsonConfiguration.Remotes[remote] = profileName
+	})
 }
 
 func (configuration *Configuration) GetProfile(name string) *Profile {
 	for _, element := range configuration.JSON.Profiles {
 		if element.Name == name {
+			element.keepSecrets()
 			return &element
 		}
 	}
@@ -106,45 +121,67 @@ func (configuration *Configuration) IsValid(profile *Profile) bool {
 		if len(profile.Jira.Token) < 2 {
 			return false
 		}
+		switch profile.Jira.Auth {
+		case "", JiraAuthBearer, JiraAuthOAuth:
+		case JiraAuthBasic:
+			if !strings.Contains(profile.Jira.Email, "@") {
+				return false
+			}
+		default:
+			return false
+		}
 	}
 
-	return true
-}
-
-func (configuration *Configuration) VersionChecked() {
-	configuration.JSON.LastVersionCheck = time.Now().Unix()
-	updateConfiguration(configuration.Path, configuration.JSON)
-}
+	if profile.Type == ProfileTypeGithub {
+		if profile.Github.BaseURL != "" {
+			parsedGithubBaseURL, parsedGithubBaseURLError := url.ParseRequestURI(profile.Github.BaseURL)
+			if parsedGithubBaseURLError != nil {
+				return false
+			}
+			if parsedGithubBaseURL.Scheme != "http" && parsedGithubBaseURL.Scheme != "https" {
+				return false
+			}
+		}
+		if profile.Github.Repository != "" && len(strings.Split(profile.Github.Repository, "/")) != 2 {
+			return false
+		}
+	}
 
-func readConfiguration(path string) (*JSONConfiguration, error) {
-	rawFileContent, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
+	if profile.Type == ProfileTypeGitlab {
+		parsedGitlabHost, parsedGitlabHostError := url.ParseRequestURI(profile.Gitlab.Host)
+		if parsedGitlabHostError != nil {
+			return false
+		}
+		if parsedGitlabHost.Scheme != "http" && parsedGitlabHost.Scheme != "https" {
+			return false
+		}
+		if len(profile.Gitlab.Token) < 2 {
+			return false
+		}
 	}
 
-	var fileContent JSONConfiguration
-	if err := json.Unmarshal(rawFileContent, &fileContent); err != nil {
-		return nil, err
+	if (profile.TLS.ClientCertificate == "") != (profile.TLS.ClientKey == "") {
+		return false
 	}
 
-	return &fileContent, nil
+	return true
 }
 
-func updateConfiguration(path string, jsonConfiguration JSONConfiguration) (*JSONConfiguration, error) {
-	jsonFileContent, err := json.Marshal(jsonConfiguration)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal new json configuration : %v", err)
+func (configuration *Configuration) VersionChecked() {
+	if err := configuration.update(func(jsonConfiguration *JSONConfiguration) {
+		jsonConfiguration.LastVersionCheck = time.Now().Unix()
+	}); err != nil {
+		configuration.logger.Debug("Unable to save version check %v", err)
 	}
+}
 
-	file, err := os.Create(path)
+// update saves changes and reloads the configuration with the changes of other gira processes
+func (configuration *Configuration) update(apply func(jsonConfiguration *JSONConfiguration)) error {
+	jsonConfiguration, err := updateConfiguration(configuration.Path, apply)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create json configuration file : %v", err)
-	}
-	defer file.Close()
-
-	if _, err := file.Write(jsonFileContent); err != nil {
-		return nil, err
+		return err
 	}
 
-	return &jsonConfiguration, nil
+	configuration.JSON = *jsonConfiguration
+	return nil
 }
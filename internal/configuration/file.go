@@ -5,11 +5,22 @@ type ProfileType string
 const (
 	ProfileTypeJira   ProfileType = "JIRA"
 	ProfileTypeGithub ProfileType = "GITHUB"
+	ProfileTypeGitlab ProfileType = "GITLAB"
+)
+
+type JiraAuth string
+
+const (
+	JiraAuthBearer JiraAuth = "BEARER"
+	JiraAuthBasic  JiraAuth = "BASIC"
+	JiraAuthOAuth  JiraAuth = "OAUTH"
 )
 
 type JSONConfiguration struct {
-	Profiles         []Profile `json:"profiles"`
-	LastVersionCheck int64     `json:"lastVersionCheck,omitempty"`
+	SchemaVersion    int               `json:"schemaVersion"`
+	Profiles         []Profile         `json:"profiles"`
+	Remotes          map[string]string `json:"remotes,omitempty"` // Git remote (host, host/owner or host/owner/repository) to profile name
+	LastVersionCheck int64             `json:"lastVersionCheck,omitempty"`
 }
 
 type Profile struct {
@@ -17,16 +28,72 @@ type Profile struct {
 	Type   ProfileType `json:"type,omitempty"`
 	Jira   Jira        `json:"jira,omitempty"`
 	Github Github      `json:"github,omitempty"`
+	Gitlab Gitlab      `json:"gitlab,omitempty"`
+	Commit Commit      `json:"commit,omitempty"`
+	Branch Branch      `json:"branch,omitempty"`
+	TLS    TLS         `json:"tls,omitempty"`
+	Dash   Dash        `json:"dash,omitempty"`
+
+	PullRequest PullRequest `json:"pullRequest,omitempty"`
+
+	secrets map[string]profileSecret
 }
 
 type Jira struct {
-	Host  string `json:"host,omitempty"`
-	Token string `json:"token,omitempty"`
-	Board string `json:"board,omitempty"`
-	JQL   string `json:"jql,omitempty"`
+	Host    string   `json:"host,omitempty"`
+	Auth    JiraAuth `json:"auth,omitempty"`
+	Email   string   `json:"email,omitempty"`
+	Token   string   `json:"token,omitempty"`
+	Project string   `json:"project,omitempty"` // Default project key of new issues
+	Board   string   `json:"board,omitempty"`
+	JQL     string   `json:"jql,omitempty"`
 }
 
 type Github struct {
-	User  string `json:"user,omitempty"`
-	Token string `json:"token,omitempty"`
+	User       string `json:"user,omitempty"`
+	Token      string `json:"token,omitempty"`
+	BaseURL    string `json:"baseURL,omitempty"`
+	Repository string `json:"repository,omitempty"`
+}
+
+type Branch struct {
+	Template      string            `json:"template,omitempty"`
+	Pattern       string            `json:"pattern,omitempty"`
+	MaxSlugLength int               `json:"maxSlugLength,omitempty"`
+	Types         map[string]string `json:"types,omitempty"`
+	DefaultType   string            `json:"defaultType,omitempty"`
+}
+
+const (
+	DashColumnAssignee = "assignee"
+	DashColumnTypes    = "types"
+	DashColumnCreated  = "created"
+	DashColumnPriority = "priority"
+)
+
+type Dash struct {
+	MaxResults int      `json:"maxResults,omitempty"`
+	Columns    []string `json:"columns,omitempty"`
+}
+
+type PullRequest struct {
+	Base  string `json:"base,omitempty"`
+	Draft bool   `json:"draft,omitempty"`
+}
+
+type Commit struct {
+	Template string `json:"template,omitempty"`
+}
+
+type TLS struct {
+	CABundle          string `json:"caBundle,omitempty"`
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+	ClientKey         string `json:"clientKey,omitempty"`
+	Insecure          bool   `json:"insecure,omitempty"`
+}
+
+type Gitlab struct {
+	Host    string `json:"host,omitempty"`
+	Token   string `json:"token,omitempty"`
+	Project string `json:"project,omitempty"`
 }
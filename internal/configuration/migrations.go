@@ -0,0 +1,43 @@
+package configuration
+
+// migrations upgrade the raw configuration file, migrations[i] upgrades the schema version i to i+1
+var migrations = []func(raw map[string]any){
+	// 0 → 1: Jira profiles without authentication mode used personal access tokens
+	func(raw map[string]any) {
+		profiles, _ := raw["profiles"].([]any)
+		for _, rawProfile := range profiles {
+			profile, ok := rawProfile.(map[string]any)
+			if !ok || profile["type"] != string(ProfileTypeJira) {
+				continue
+			}
+
+			jira, ok := profile["jira"].(map[string]any)
+			if !ok {
+				jira = map[string]any{}
+				profile["jira"] = jira
+			}
+			if auth, _ := jira["auth"].(string); auth == "" {
+				jira["auth"] = string(JiraAuthBearer)
+			}
+		}
+	},
+}
+
+// SchemaVersion returns the version of the configuration file written by this binary
+func SchemaVersion() int {
+	return len(migrations)
+}
+
+// migrate upgrades the raw configuration to the current schema version, newer versions are kept as is
+func migrate(raw map[string]any) {
+	version := 0
+	if rawVersion, ok := raw["schemaVersion"].(float64); ok {
+		version = int(rawVersion)
+	}
+
+	for ; version < SchemaVersion(); version++ {
+		migrations[version](raw)
+	}
+
+	raw["schemaVersion"] = version
+}
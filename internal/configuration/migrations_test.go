@@ -0,0 +1,85 @@
+package configuration
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			"jira without authentication mode",
+			`{"profiles":[{"name":"work","type":"JIRA","jira":{"host":"jira.acme.com"}}]}`,
+			`{"schemaVersion":1,"profiles":[{"name":"work","type":"JIRA","jira":{"host":"jira.acme.com","auth":"BEARER"}}]}`,
+		},
+		{
+			"jira without settings",
+			`{"profiles":[{"name":"work","type":"JIRA"}]}`,
+			`{"schemaVersion":1,"profiles":[{"name":"work","type":"JIRA","jira":{"auth":"BEARER"}}]}`,
+		},
+		{
+			"jira with authentication mode",
+			`{"profiles":[{"name":"work","type":"JIRA","jira":{"auth":"BASIC"}}]}`,
+			`{"schemaVersion":1,"profiles":[{"name":"work","type":"JIRA","jira":{"auth":"BASIC"}}]}`,
+		},
+		{
+			"github",
+			`{"profiles":[{"name":"oss","type":"GITHUB"}]}`,
+			`{"schemaVersion":1,"profiles":[{"name":"oss","type":"GITHUB"}]}`,
+		},
+		{
+			"already migrated",
+			`{"schemaVersion":1,"profiles":[{"name":"work","type":"JIRA","jira":{}}]}`,
+			`{"schemaVersion":1,"profiles":[{"name":"work","type":"JIRA","jira":{}}]}`,
+		},
+		{
+			"newer version",
+			`{"schemaVersion":5,"profiles":[{"name":"work","type":"JIRA"}]}`,
+			`{"schemaVersion":5,"profiles":[{"name":"work","type":"JIRA"}]}`,
+		},
+		{
+			"empty file",
+			`{}`,
+			`{"schemaVersion":1}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			raw := map[string]any{}
+			if err := json.Unmarshal([]byte(test.raw), &raw); err != nil {
+				t.Fatal(err)
+			}
+			migrate(raw)
+
+			want := map[string]any{}
+			if err := json.Unmarshal([]byte(test.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := json.Marshal(raw); !reflect.DeepEqual(roundTrip(t, raw), want) {
+				t.Errorf("migrate() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+// roundTrip returns the value as decoded from JSON, numbers become float64
+func roundTrip(t *testing.T, value any) map[string]any {
+	t.Helper()
+	content, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := map[string]any{}
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	return decoded
+}
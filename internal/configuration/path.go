@@ -0,0 +1,189 @@
+package configuration
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Paths returns the settable fields of a profile as dotted JSON names (e.g. jira.host), map fields accept one more key (e.g. branch.types.story)
+func Paths() []string {
+	paths := []string{}
+	var walk func(prefix string, objectType reflect.Type)
+	walk = func(prefix string, objectType reflect.Type) {
+		for name, field := range jsonFields(objectType) {
+			if field.Type.Kind() == reflect.Struct {
+				walk(prefix+name+".", field.Type)
+				continue
+			}
+			if prefix+name != "name" {
+				paths = append(paths, prefix+name)
+			}
+		}
+	}
+	walk("", reflect.TypeOf(Profile{}))
+	slices.Sort(paths)
+
+	return paths
+}
+
+// IsSecretPath returns true for the token fields, which are stored through the secret backends
+func IsSecretPath(path string) bool {
+	return strings.EqualFold(path, "jira.token") || strings.EqualFold(path, "github.token") || strings.EqualFold(path, "gitlab.token")
+}
+
+// Get returns the value of a field, lists are comma separated
+func (profile *Profile) Get(path string) (string, error) {
+	value, key, err := profile.field(path)
+	if err != nil {
+		return "", err
+	}
+
+	switch value.Kind() {
+	case reflect.Map:
+		if key == "" {
+			entries := []string{}
+			for _, mapKey := range value.MapKeys() {
+				entries = append(entries, mapKey.String()+"="+value.MapIndex(mapKey).String())
+			}
+			slices.Sort(entries)
+			return strings.Join(entries, ","), nil
+		}
+		if mapValue := value.MapIndex(reflect.ValueOf(key)); mapValue.IsValid() {
+			return mapValue.String(), nil
+		}
+		return "", nil
+	case reflect.Slice:
+		items := []string{}
+		for index := range value.Len() {
+			items = append(items, value.Index(index).String())
+		}
+		return strings.Join(items, ","), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int:
+		return strconv.FormatInt(value.Int(), 10), nil
+	default:
+		return value.String(), nil
+	}
+}
+
+// Set parses and sets the value of a field, an empty value clears it
+func (profile *Profile) Set(path string, rawValue string) error {
+	value, key, err := profile.field(path)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(path, "branch.template") {
+		if err := ValidateBranchTemplate(rawValue); err != nil {
+			return err
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Map:
+		// Without key, the whole map is replaced by key=value pairs
+		if key == "" {
+			entries := map[string]string{}
+			for _, entry := range strings.Split(rawValue, ",") {
+				if entry = strings.TrimSpace(entry); entry == "" {
+					continue
+				}
+				entryKey, entryValue, ok := strings.Cut(entry, "=")
+				if !ok {
+					return fmt.Errorf("%s expects key=value pairs, got %s", path, entry)
+				}
+				entries[strings.TrimSpace(entryKey)] = strings.TrimSpace(entryValue)
+			}
+			value.Set(reflect.ValueOf(entries))
+			return nil
+		}
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+		if rawValue == "" {
+			value.SetMapIndex(reflect.ValueOf(key), reflect.Value{})
+		} else {
+			value.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(rawValue))
+		}
+	case reflect.Slice:
+		items := []string{}
+		for _, item := range strings.Split(rawValue, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	case reflect.Bool:
+		parsedValue := false
+		if rawValue != "" {
+			if parsedValue, err = strconv.ParseBool(rawValue); err != nil {
+				return fmt.Errorf("%s expects true or false, got %s", path, rawValue)
+			}
+		}
+		value.SetBool(parsedValue)
+	case reflect.Int:
+		parsedValue := 0
+		if rawValue != "" {
+			if parsedValue, err = strconv.Atoi(rawValue); err != nil {
+				return fmt.Errorf("%s expects a number, got %s", path, rawValue)
+			}
+		}
+		value.SetInt(int64(parsedValue))
+	default:
+		// Enumerations like type or jira.auth are upper case
+		if value.Type() == reflect.TypeOf(ProfileType("")) || value.Type() == reflect.TypeOf(JiraAuth("")) {
+			rawValue = strings.ToUpper(rawValue)
+		}
+		value.SetString(rawValue)
+	}
+
+	return nil
+}
+
+// field returns the settable value of a path, and the key of map fields
+func (profile *Profile) field(path string) (reflect.Value, string, error) {
+	if strings.EqualFold(path, "name") {
+		return reflect.Value{}, "", fmt.Errorf("the profile name is selected with --profile")
+	}
+
+	value := reflect.ValueOf(profile).Elem()
+	parts := strings.Split(path, ".")
+	for index, part := range parts {
+		switch value.Kind() {
+		case reflect.Struct:
+			field, ok := findField(value.Type(), part)
+			if !ok {
+				return reflect.Value{}, "", fmt.Errorf("unknown field %s", path)
+			}
+			value = value.FieldByIndex(field.Index)
+		case reflect.Map:
+			if index == len(parts)-1 {
+				return value, part, nil
+			}
+			return reflect.Value{}, "", fmt.Errorf("unknown field %s", path)
+		default:
+			return reflect.Value{}, "", fmt.Errorf("unknown field %s", path)
+		}
+	}
+
+	if value.Kind() == reflect.Struct {
+		return reflect.Value{}, "", fmt.Errorf("unknown field %s, use a full path like jira.host", path)
+	}
+
+	return value, "", nil
+}
+
+// findField finds a field by JSON name, ignoring case
+func findField(objectType reflect.Type, name string) (reflect.StructField, bool) {
+	for fieldName, field := range jsonFields(objectType) {
+		if strings.EqualFold(fieldName, name) {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
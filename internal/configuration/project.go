@@ -0,0 +1,146 @@
+package configuration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Ealenn/gira/internal/log"
+)
+
+// ProjectFileName is the repository configuration file, checked in with the code
+const ProjectFileName = ".gira"
+
+// Project pins the settings of a repository, tokens stay in the user configuration
+type Project struct {
+	Profile     string             `json:"profile,omitempty"`
+	Jira        ProjectJira        `json:"jira,omitempty"`
+	Branch      Branch             `json:"branch,omitempty"`
+	Commit      Commit             `json:"commit,omitempty"`
+	PullRequest ProjectPullRequest `json:"pullRequest,omitempty"`
+
+	Path string `json:"-"`
+}
+
+// ProjectPullRequest overrides the pull request settings, draft is only overridden when it is set, to true or false
+type ProjectPullRequest struct {
+	Base  string `json:"base,omitempty"`
+	Draft *bool  `json:"draft,omitempty"`
+}
+
+type ProjectJira struct {
+	Project string `json:"project,omitempty"`
+	Board   string `json:"board,omitempty"`
+	JQL     string `json:"jql,omitempty"`
+}
+
+// FindProject looks for a project file from the current directory up to the Git root, nil when there is none
+func FindProject(logger *log.Logger, root string) (*Project, error) {
+	if root == "" {
+		return nil, nil
+	}
+
+	directory, err := os.Getwd()
+	if err != nil {
+		logger.Debug("Unable to find current directory %v", err)
+		return nil, nil
+	}
+
+	userConfigurationPath := ""
+	if homeDirPath, err := os.UserHomeDir(); err == nil {
+		userConfigurationPath = filepath.Join(homeDirPath, ".gira")
+	}
+
+	for {
+		path := filepath.Join(directory, ProjectFileName)
+		if path != userConfigurationPath {
+			project, err := readProject(path)
+			if err == nil {
+				logger.Debug("Project configuration : %s", path)
+				return project, nil
+			}
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("%s is invalid, %w", path, err)
+			}
+		}
+
+		parent := filepath.Dir(directory)
+		if directory == filepath.Clean(root) || parent == directory {
+			return nil, nil
+		}
+		directory = parent
+	}
+}
+
+// Apply overrides the profile settings with the settings pinned by the project
+func (project *Project) Apply(profile *Profile) {
+	overrideString(&profile.Jira.Project, project.Jira.Project)
+	overrideString(&profile.Jira.Board, project.Jira.Board)
+	overrideString(&profile.Jira.JQL, project.Jira.JQL)
+
+	overrideString(&profile.Branch.Template, project.Branch.Template)
+	overrideString(&profile.Branch.Pattern, project.Branch.Pattern)
+	overrideString(&profile.Branch.DefaultType, project.Branch.DefaultType)
+	if project.Branch.MaxSlugLength > 0 {
+		profile.Branch.MaxSlugLength = project.Branch.MaxSlugLength
+	}
+	if len(project.Branch.Types) > 0 {
+		types := map[string]string{}
+		for issueType, prefix := range profile.Branch.Types {
+			types[issueType] = prefix
+		}
+		for issueType, prefix := range project.Branch.Types {
+			types[issueType] = prefix
+		}
+		profile.Branch.Types = types
+	}
+
+	overrideString(&profile.Commit.Template, project.Commit.Template)
+
+	overrideString(&profile.PullRequest.Base, project.PullRequest.Base)
+	if project.PullRequest.Draft != nil {
+		profile.PullRequest.Draft = *project.PullRequest.Draft
+	}
+}
+
+// GetRemoteProfile returns the profile mapped to the Git remote, the longest match of host, host/owner or host/owner/repository wins
+func (configuration *Configuration) GetRemoteProfile(host string, path string) string {
+	remote := strings.ToLower(host + "/" + path + "/")
+
+	profileName, matchLength := "", 0
+	for pattern, name := range configuration.JSON.Remotes {
+		pattern = strings.ToLower(strings.Trim(pattern, "/"))
+		if strings.HasPrefix(remote, pattern+"/") && len(pattern) > matchLength {
+			profileName, matchLength = name, len(pattern)
+		}
+	}
+
+	return profileName
+}
+
+func overrideString(value *string, override string) {
+	if override != "" {
+		*value = override
+	}
+}
+
+func readProject(path string) (*Project, error) {
+	rawFileContent, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := json.Unmarshal(rawFileContent, &project); err != nil {
+		return nil, err
+	}
+	if err := ValidateBranchTemplate(project.Branch.Template); err != nil {
+		return nil, err
+	}
+	project.Path = path
+
+	return &project, nil
+}
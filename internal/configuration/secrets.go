@@ -0,0 +1,137 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Ealenn/gira/internal/secret"
+)
+
+// profileSecret keeps the value of the configuration file (plaintext or reference) next to the resolved secret
+type profileSecret struct {
+	raw      string
+	resolved string
+	loaded   bool // References are resolved when a client needs the token, they may run commands
+}
+
+type secretField struct {
+	name   string
+	value  *string
+	suffix string // Suffix of the environment variable overriding the secret
+}
+
+func (profile *Profile) secretFields() []secretField {
+	switch profile.Type {
+	case ProfileTypeJira:
+		return []secretField{
+			{"jira", &profile.Jira.Token, "TOKEN"},
+			{"github", &profile.Github.Token, "GITHUB_TOKEN"},
+		}
+	case ProfileTypeGithub:
+		return []secretField{{"github", &profile.Github.Token, "TOKEN"}}
+	case ProfileTypeGitlab:
+		return []secretField{{"gitlab", &profile.Gitlab.Token, "TOKEN"}}
+	default:
+		return nil
+	}
+}
+
+// keepSecrets remembers the saved tokens of the profile, they are resolved later by ResolveSecret
+func (profile *Profile) keepSecrets() {
+	profile.secrets = map[string]profileSecret{}
+	for _, field := range profile.secretFields() {
+		profile.secrets[field.name] = profileSecret{raw: *field.value, resolved: *field.value}
+	}
+}
+
+// ResolveSecret replaces the reference of a token (jira, github or gitlab) by its secret, the environment variable takes precedence
+func (profile *Profile) ResolveSecret(name string) error {
+	for _, field := range profile.secretFields() {
+		previous, ok := profile.secrets[field.name]
+		if field.name != name || (ok && previous.loaded) {
+			continue
+		}
+
+		raw := *field.value
+		if ok {
+			raw = previous.raw
+		}
+
+		resolved, found := os.LookupEnv(secret.EnvVariable(profile.Name, field.suffix))
+		var err error
+		if !found {
+			if resolved, err = secret.Resolve(raw); err != nil {
+				err = fmt.Errorf("unable to read the %s token of profile %s: %w", field.name, profile.Name, err)
+			}
+		}
+
+		if profile.secrets == nil {
+			profile.secrets = map[string]profileSecret{}
+		}
+		*field.value = resolved
+		profile.secrets[field.name] = profileSecret{raw, resolved, true}
+		return err
+	}
+
+	return nil
+}
+
+// ResolveTrackerSecret resolves the token of the issue tracker of the profile
+func (profile *Profile) ResolveTrackerSecret() error {
+	fields := profile.secretFields()
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return profile.ResolveSecret(fields[0].name)
+}
+
+// ResolveSecrets resolves every token of the profile, e.g. to export them
+func (profile *Profile) ResolveSecrets() error {
+	errs := []error{}
+	for _, field := range profile.secretFields() {
+		errs = append(errs, profile.ResolveSecret(field.name))
+	}
+
+	return errors.Join(errs...)
+}
+
+// storeSecrets replaces the secrets of the profile by references before saving, unchanged tokens keep their saved value so environment overrides are never saved
+func (configuration *Configuration) storeSecrets(profile *Profile) {
+	for _, field := range profile.secretFields() {
+		if previous, ok := profile.secrets[field.name]; ok && previous.resolved == *field.value {
+			*field.value = previous.raw
+			continue
+		}
+
+		reference, err := secret.Store(profile.Name+"/"+field.name, *field.value)
+		if err != nil {
+			configuration.logger.Debug("%v", err)
+			configuration.logger.Warn("⚠️ OS keyring unavailable, the %s token is saved in %s", field.name, configuration.Path)
+			continue
+		}
+		*field.value = reference
+	}
+}
+
+// PlaintextSecrets returns the names of the tokens saved in the configuration file instead of a reference
+func (profile Profile) PlaintextSecrets() []string {
+	names := []string{}
+	for _, field := range profile.secretFields() {
+		if *field.value != "" && !secret.IsReference(*field.value) {
+			names = append(names, field.name)
+		}
+	}
+
+	return names
+}
+
+// deleteSecrets removes the keyring secrets of a removed profile
+func (configuration *Configuration) deleteSecrets(profile Profile) {
+	for _, field := range profile.secretFields() {
+		if err := secret.Delete(*field.value); err != nil {
+			configuration.logger.Debug("Unable to delete the %s token of profile %s: %v", field.name, profile.Name, err)
+		}
+	}
+}
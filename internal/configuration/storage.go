@@ -0,0 +1,178 @@
+package configuration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// readConfiguration returns the migrated configuration and the raw file content, which keeps the fields unknown to this binary
+func readConfiguration(path string) (*JSONConfiguration, map[string]any, error) {
+	rawFileContent, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw := map[string]any{}
+	if err := json.Unmarshal(rawFileContent, &raw); err != nil {
+		return nil, nil, err
+	}
+	migrate(raw)
+
+	migratedFileContent, err := json.Marshal(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fileContent JSONConfiguration
+	if err := json.Unmarshal(migratedFileContent, &fileContent); err != nil {
+		return nil, nil, err
+	}
+
+	return &fileContent, raw, nil
+}
+
+// updateConfiguration applies changes to the latest configuration file while holding the lock, other gira processes may have changed it
+func updateConfiguration(path string, apply func(jsonConfiguration *JSONConfiguration)) (*JSONConfiguration, error) {
+	lock, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open json configuration lock : %v", err)
+	}
+	defer lock.Close()
+
+	if err := lockFile(lock); err != nil {
+		return nil, fmt.Errorf("failed to lock json configuration : %v", err)
+	}
+	defer unlockFile(lock)
+
+	jsonConfiguration, raw, err := readConfiguration(path)
+	if errors.Is(err, os.ErrNotExist) {
+		jsonConfiguration, raw = &JSONConfiguration{Profiles: []Profile{}, SchemaVersion: SchemaVersion()}, map[string]any{}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read json configuration : %v", err)
+	}
+
+	apply(jsonConfiguration)
+
+	if err := writeConfiguration(path, jsonConfiguration, raw); err != nil {
+		return nil, err
+	}
+
+	return jsonConfiguration, nil
+}
+
+// writeConfiguration replaces the file through a temporary file, the configuration is never partially written
+func writeConfiguration(path string, jsonConfiguration *JSONConfiguration, raw map[string]any) error {
+	jsonFileContent, err := json.Marshal(jsonConfiguration)
+	if err != nil {
+		return fmt.Errorf("failed to marshal new json configuration : %v", err)
+	}
+
+	updated := map[string]any{}
+	if err := json.Unmarshal(jsonFileContent, &updated); err != nil {
+		return fmt.Errorf("failed to marshal new json configuration : %v", err)
+	}
+	preserveUnknownFields(raw, updated, reflect.TypeOf(JSONConfiguration{}))
+
+	if jsonFileContent, err = json.Marshal(updated); err != nil {
+		return fmt.Errorf("failed to marshal new json configuration : %v", err)
+	}
+
+	// Keep symbolic links, e.g. to a dotfiles repository
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+
+	// Tokens can be kept in plaintext when no secret backend is available
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create json configuration file : %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if err := file.Chmod(0o600); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to restrict json configuration file permissions : %v", err)
+	}
+	if _, err := file.Write(jsonFileContent); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+// preserveUnknownFields copies the fields written by other gira versions into the updated content, profiles are matched by name
+func preserveUnknownFields(original map[string]any, updated map[string]any, objectType reflect.Type) {
+	fields := jsonFields(objectType)
+	for key, originalValue := range original {
+		field, known := fields[key]
+		if !known {
+			updated[key] = originalValue
+			continue
+		}
+
+		fieldType := field.Type
+
+		switch fieldType.Kind() {
+		case reflect.Struct:
+			originalObject, isOriginalObject := originalValue.(map[string]any)
+			updatedObject, isUpdatedObject := updated[key].(map[string]any)
+			if isOriginalObject && isUpdatedObject {
+				preserveUnknownFields(originalObject, updatedObject, fieldType)
+			}
+		case reflect.Slice:
+			originalItems, isOriginalSlice := originalValue.([]any)
+			updatedItems, isUpdatedSlice := updated[key].([]any)
+			if fieldType.Elem().Kind() != reflect.Struct || !isOriginalSlice || !isUpdatedSlice {
+				continue
+			}
+
+			for _, updatedItem := range updatedItems {
+				updatedObject, ok := updatedItem.(map[string]any)
+				if !ok {
+					continue
+				}
+				for _, originalItem := range originalItems {
+					if originalObject, ok := originalItem.(map[string]any); ok && originalObject["name"] != nil && originalObject["name"] == updatedObject["name"] {
+						preserveUnknownFields(originalObject, updatedObject, fieldType.Elem())
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// jsonFields returns the exported fields of a struct by JSON name
+func jsonFields(objectType reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for index := range objectType.NumField() {
+		field := objectType.Field(index)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field
+	}
+
+	return fields
+}
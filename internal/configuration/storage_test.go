@@ -0,0 +1,109 @@
+package configuration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPreserveUnknownFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		updated  string
+		want     string
+	}{
+		{
+			"top level field",
+			`{"profiles":[],"theme":"dark"}`,
+			`{"profiles":[]}`,
+			`{"profiles":[],"theme":"dark"}`,
+		},
+		{
+			"profile field matched by name",
+			`{"profiles":[{"name":"oss","avatar":"cat"},{"name":"work","avatar":"dog"}]}`,
+			`{"profiles":[{"name":"work"},{"name":"oss"}]}`,
+			`{"profiles":[{"name":"work","avatar":"dog"},{"name":"oss","avatar":"cat"}]}`,
+		},
+		{
+			"nested profile field",
+			`{"profiles":[{"name":"work","jira":{"host":"old.acme.com","sprint":"current"}}]}`,
+			`{"profiles":[{"name":"work","jira":{"host":"jira.acme.com"}}]}`,
+			`{"profiles":[{"name":"work","jira":{"host":"jira.acme.com","sprint":"current"}}]}`,
+		},
+		{
+			"removed known field",
+			`{"profiles":[{"name":"work","jira":{"host":"jira.acme.com","board":"12"}}]}`,
+			`{"profiles":[{"name":"work","jira":{"host":"jira.acme.com"}}]}`,
+			`{"profiles":[{"name":"work","jira":{"host":"jira.acme.com"}}]}`,
+		},
+		{
+			"removed profile",
+			`{"profiles":[{"name":"work","avatar":"dog"}]}`,
+			`{"profiles":[]}`,
+			`{"profiles":[]}`,
+		},
+		{
+			"renamed profile",
+			`{"profiles":[{"name":"work","avatar":"dog"}]}`,
+			`{"profiles":[{"name":"job"}]}`,
+			`{"profiles":[{"name":"job"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			original, updated, want := decode(t, test.original), decode(t, test.updated), decode(t, test.want)
+			preserveUnknownFields(original, updated, reflect.TypeOf(JSONConfiguration{}))
+			if !reflect.DeepEqual(updated, want) {
+				got, _ := json.Marshal(updated)
+				t.Errorf("preserveUnknownFields() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUpdateConfiguration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{"profiles":[{"name":"work","type":"JIRA","jira":{"host":"jira.acme.com"},"avatar":"dog"}],"theme":"dark"}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	jsonConfiguration, err := updateConfiguration(path, func(jsonConfiguration *JSONConfiguration) {
+		jsonConfiguration.Profiles[0].Jira.Board = "12"
+	})
+	if err != nil {
+		t.Fatalf("updateConfiguration() returned %v", err)
+	}
+	if auth := jsonConfiguration.Profiles[0].Jira.Auth; auth != JiraAuthBearer {
+		t.Errorf("jira auth = %s, want %s", auth, JiraAuthBearer)
+	}
+
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := decode(t, string(written))
+	profile := raw["profiles"].([]any)[0].(map[string]any)
+	want := decode(t, `{"host":"jira.acme.com","auth":"BEARER","board":"12"}`)
+	if !reflect.DeepEqual(profile["jira"], any(want)) || profile["avatar"] != "dog" || raw["theme"] != "dark" || raw["schemaVersion"] != float64(1) {
+		t.Errorf("configuration file = %s, want the migrated settings, the new board and the unknown fields", written)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("configuration file mode = %v (%v), want %v", info.Mode().Perm(), err, os.FileMode(0o600))
+	}
+}
+
+func decode(t *testing.T, content string) map[string]any {
+	t.Helper()
+	decoded := map[string]any{}
+	if err := json.Unmarshal([]byte(content), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	return decoded
+}
@@ -0,0 +1,99 @@
+package configuration
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/Ealenn/gira/internal/secret"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RedactedSecret replaces plaintext tokens in exports, it is ignored on import
+const RedactedSecret = "<redacted>"
+
+// Redacted returns a copy of the profile without plaintext tokens, references to secret backends are kept
+func (profile Profile) Redacted() Profile {
+	for _, field := range profile.secretFields() {
+		if *field.value != "" && !secret.IsReference(*field.value) {
+			*field.value = RedactedSecret
+		}
+	}
+
+	return profile
+}
+
+// KeepSecrets restores the redacted tokens of an imported profile from the existing profile, as saved with its references
+func (profile *Profile) KeepSecrets(existing *Profile) {
+	for _, field := range profile.secretFields() {
+		if *field.value != RedactedSecret {
+			continue
+		}
+
+		*field.value = ""
+		if existing != nil {
+			existingValue, _ := existing.Get(field.name + ".token")
+			*field.value = existingValue
+		}
+	}
+}
+
+// CommandSecrets returns the names of the tokens read by running a command (cmd: references)
+func (profile Profile) CommandSecrets() []string {
+	names := []string{}
+	for _, field := range profile.secretFields() {
+		if strings.HasPrefix(*field.value, secret.PrefixCommand) {
+			names = append(names, field.name)
+		}
+	}
+
+	return names
+}
+
+// SetFromEnv sets the fields found in GIRA_IMPORT_<FIELD> environment variables (e.g. GIRA_IMPORT_JIRA_HOST), it returns the fields set
+func (profile *Profile) SetFromEnv() ([]string, error) {
+	paths := []string{}
+	for _, path := range Paths() {
+		variable := "GIRA_IMPORT_" + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+		if value, ok := os.LookupEnv(variable); ok {
+			if err := profile.Set(path, value); err != nil {
+				return nil, err
+			}
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+// ParseProfiles reads exported profiles, as JSON or YAML, a single profile without name is named defaultName
+func ParseProfiles(rawContent []byte, defaultName string) ([]Profile, error) {
+	var content any
+	if err := yaml.Unmarshal(rawContent, &content); err != nil {
+		return nil, err
+	}
+
+	jsonContent, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if object, ok := content.(map[string]any); ok && object["profiles"] != nil {
+		var exportedConfiguration JSONConfiguration
+		if err := json.Unmarshal(jsonContent, &exportedConfiguration); err != nil {
+			return nil, err
+		}
+		return exportedConfiguration.Profiles, nil
+	}
+
+	var profile Profile
+	if err := json.Unmarshal(jsonContent, &profile); err != nil {
+		return nil, err
+	}
+	if profile.Name == "" {
+		profile.Name = defaultName
+	}
+
+	return []Profile{profile}, nil
+}
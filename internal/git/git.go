@@ -1,6 +1,7 @@
 package git
 
 import (
+	"fmt"
 	"os/exec"
 	"strings"
 
@@ -28,16 +29,50 @@ func (git *Git) CreateBranch(name string) []byte {
 	return output
 }
 
-func (git *Git) CurrentOrigin() string {
+func (git *Git) CurrentOrigin() (string, error) {
 	cmd := exec.Command("git", "config", "--get", "remote.origin.url")
 	output, err := cmd.Output()
 	if err != nil {
-		git.logger.Fatal("Unable to get Git Origin URL from current folder")
+		return "", fmt.Errorf("unable to get Git Origin URL from current folder: %w", err)
 	}
 
 	origin := strings.TrimSpace(string(output))
 	git.logger.Debug("Git repository URL: %s", origin)
-	return origin
+	return origin, nil
+}
+
+// Root returns the top level directory of the current repository
+func (git *Git) Root() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("unable to find the Git repository of the current folder: %w", err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (git *Git) CurrentRemote() (*Remote, error) {
+	origin, err := git.CurrentOrigin()
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRemote(origin)
+}
+
+func (git *Git) CurrentUser() (string, error) {
+	cmd := exec.Command("git", "config", "--get", "user.email")
+	output, err := cmd.Output()
+	if err == nil && strings.TrimSpace(string(output)) != "" {
+		user, _, _ := strings.Cut(strings.TrimSpace(string(output)), "@")
+		return user, nil
+	}
+
+	cmd = exec.Command("git", "config", "--get", "user.name")
+	output, err = cmd.Output()
+
+	return strings.TrimSpace(string(output)), err
 }
 
 func (git *Git) CurrentBranch() (string, error) {
@@ -47,6 +82,44 @@ func (git *Git) CurrentBranch() (string, error) {
 	return strings.TrimSpace(string(response)), err
 }
 
+func (git *Git) StagedDiff() (string, error) {
+	cmd := exec.Command("git", "diff", "--cached")
+	output, err := cmd.Output()
+
+	return string(output), err
+}
+
+func (git *Git) StagedDiffStat() (string, error) {
+	cmd := exec.Command("git", "diff", "--cached", "--stat")
+	output, err := cmd.Output()
+
+	return strings.TrimSpace(string(output)), err
+}
+
+func (git *Git) Commit(message string) ([]byte, error) {
+	cmd := exec.Command("git", "commit", "-m", message)
+	return cmd.CombinedOutput()
+}
+
+func (git *Git) Push(branch string) ([]byte, error) {
+	cmd := exec.Command("git", "push", "--set-upstream", "origin", branch)
+	return cmd.CombinedOutput()
+}
+
+func (git *Git) Log(base string) (string, error) {
+	cmd := exec.Command("git", "log", "--no-merges", "--format=%s%n%b", base+"..HEAD")
+	output, err := cmd.Output()
+
+	return strings.TrimSpace(string(output)), err
+}
+
+func (git *Git) Diff(base string) (string, error) {
+	cmd := exec.Command("git", "diff", base+"...HEAD")
+	output, err := cmd.Output()
+
+	return string(output), err
+}
+
 func (git *Git) IsBranchExist(name string) bool {
 	cmd := exec.Command("git", "rev-parse", "--verify", name)
 	_, err := cmd.CombinedOutput()
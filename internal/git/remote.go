@@ -0,0 +1,57 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+type Remote struct {
+	Host       string
+	Owner      string
+	Repository string
+}
+
+// Path returns the full project path, owner can contain subgroups on GitLab (group/subgroup)
+func (remote *Remote) Path() string {
+	return remote.Owner + "/" + remote.Repository
+}
+
+var scpLikeRemote = regexp.MustCompile(`^(?:[\w.-]+@)?([\w.-]+):(.+)$`)
+
+// ParseRemote supports scp-like (git@host:owner/repo.git), ssh://, git://, http:// and https:// remote URLs
+func ParseRemote(rawURL string) (*Remote, error) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	var host, path string
+	if strings.Contains(rawURL, "://") {
+		parsedURL, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Git remote URL %s: %w", rawURL, err)
+		}
+		host = parsedURL.Hostname()
+		path = parsedURL.Path
+	} else {
+		matches := scpLikeRemote.FindStringSubmatch(rawURL)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid Git remote URL %s", rawURL)
+		}
+		host = matches[1]
+		path = matches[2]
+	}
+
+	path = strings.Trim(path, "/")
+	path = strings.TrimSuffix(path, ".git")
+
+	separator := strings.LastIndex(path, "/")
+	if host == "" || separator <= 0 || separator == len(path)-1 {
+		return nil, fmt.Errorf("invalid Git remote URL %s, expected owner/repository", rawURL)
+	}
+
+	return &Remote{
+		Host:       strings.ToLower(host),
+		Owner:      path[:separator],
+		Repository: path[separator+1:],
+	}, nil
+}
@@ -0,0 +1,398 @@
+package issue
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Ealenn/gira/internal/log"
+)
+
+// DefaultCacheTTL is the age after which cached issues and searches are fetched again
+const DefaultCacheTTL = 10 * time.Minute
+
+// cacheRetention is the age after which stale issues and searches are dropped, they are only kept for offline use
+const cacheRetention = 30 * 24 * time.Hour
+
+var ErrOffline = errors.New("not available offline, run without --offline")
+
+type CacheMode string
+
+const (
+	CacheModeDefault CacheMode = "DEFAULT" // Serve fresh entries from the cache, stale entries when the tracker is unreachable
+	CacheModeOffline CacheMode = "OFFLINE" // Serve every entry from the cache, never call the tracker
+	CacheModeRefresh CacheMode = "REFRESH" // Always call the tracker and update the cache
+)
+
+type cachedIssue struct {
+	Issue     *Issue    `json:"issue"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+type cachedSearch struct {
+	IssueIDs  []string  `json:"issues"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+type jsonCache struct {
+	Issues   map[string]cachedIssue  `json:"issues"`
+	Searches map[string]cachedSearch `json:"searches"`
+}
+
+// cacheStore is shared by the trackers of a profile, whatever their cache mode
+type cacheStore struct {
+	mutex sync.Mutex
+	path  string
+}
+
+// CachedTracker decorates a Tracker with a local cache of issues and searches, one file per profile and tracker scope in the user cache dir
+type CachedTracker struct {
+	logger  *log.Logger
+	tracker Tracker
+	store   *cacheStore
+	ttl     time.Duration
+	mode    CacheMode
+}
+
+// NewCachedTracker returns the tracker without cache when there is no user cache dir, the cache is optional
+func NewCachedTracker(logger *log.Logger, tracker Tracker, profileName string, ttl time.Duration, mode CacheMode) Tracker {
+	cacheDirPath, cacheDirPathError := os.UserCacheDir()
+	if cacheDirPathError != nil {
+		logger.Debug("Unable to find cache directory, issues are not cached %v", cacheDirPathError)
+		return tracker
+	}
+
+	return &CachedTracker{
+		logger,
+		tracker,
+		&cacheStore{path: filepath.Join(cacheDirPath, "gira", getCacheFileName(logger, tracker, profileName))},
+		ttl,
+		mode,
+	}
+}
+
+// getCacheFileName separates the issues of each repository or board, GitHub and GitLab issue numbers are reused across repositories
+func getCacheFileName(logger *log.Logger, tracker Tracker, profileName string) string {
+	scoper, ok := tracker.(Scoper)
+	if !ok {
+		return profileName + ".json"
+	}
+
+	scope := scoper.Scope()
+	logger.Debug("🗃️ Cache scope %s", scope)
+	hash := sha256.Sum256([]byte(scope))
+	return profileName + "-" + hex.EncodeToString(hash[:8]) + ".json"
+}
+
+// Unwrap returns the tracker without cache, e.g. to check optional interfaces like PullRequestCreator
+func Unwrap(tracker Tracker) Tracker {
+	if cachedTracker, ok := tracker.(*CachedTracker); ok {
+		return cachedTracker.tracker
+	}
+
+	return tracker
+}
+
+// GetScope returns the scope of the tracker, empty when it has none
+func GetScope(tracker Tracker) string {
+	if scoper, ok := Unwrap(tracker).(Scoper); ok {
+		return scoper.Scope()
+	}
+
+	return ""
+}
+
+// Fresh returns a tracker that bypasses the cache for reads, unless the cache is used offline
+func Fresh(tracker Tracker) Tracker {
+	if cachedTracker, ok := tracker.(*CachedTracker); ok && cachedTracker.mode != CacheModeOffline {
+		freshTracker := *cachedTracker
+		freshTracker.mode = CacheModeRefresh
+		return &freshTracker
+	}
+
+	return tracker
+}
+
+func (tracker *CachedTracker) SearchIssues(options SearchOptions, onPage PageHandler) (map[string]*Issue, error) {
+	key := tracker.getSearchKey(options)
+	cache := tracker.load()
+
+	if tracker.mode != CacheModeRefresh {
+		if issues, orderedIssues, fetchedAt, ok := tracker.getSearch(cache, key); ok && (tracker.mode == CacheModeOffline || tracker.isFresh(fetchedAt)) {
+			tracker.logger.Debug("🗃️ Search served from cache (%s old)", time.Since(fetchedAt).Round(time.Second).String())
+			notifyPage(onPage, orderedIssues)
+			return issues, nil
+		}
+		if tracker.mode == CacheModeOffline {
+			return nil, ErrOffline
+		}
+	}
+
+	// Pages are delivered in the order of the search, e.g. sorted by update date
+	issueIDs := []string{}
+	issues, err := tracker.tracker.SearchIssues(options, func(pageIssues []*Issue) {
+		for _, currentIssue := range pageIssues {
+			issueIDs = append(issueIDs, currentIssue.ID)
+		}
+		notifyPage(onPage, pageIssues)
+	})
+	if err != nil {
+		if staleIssues, orderedIssues, fetchedAt, ok := tracker.getSearch(cache, key); ok && errors.Is(err, ErrNetwork) {
+			tracker.logger.Warn("⚠️ Tracker unreachable, showing issues cached %s ago", time.Since(fetchedAt).Round(time.Minute).String())
+			notifyPage(onPage, orderedIssues)
+			return staleIssues, nil
+		}
+		return nil, err
+	}
+
+	tracker.update(func(cache *jsonCache) {
+		search := cachedSearch{IssueIDs: []string{}, FetchedAt: time.Now()}
+		for _, issueID := range issueIDs {
+			if currentIssue, ok := issues[issueID]; ok && !slices.Contains(search.IssueIDs, issueID) {
+				cache.Issues[issueID] = cachedIssue{currentIssue, search.FetchedAt}
+				search.IssueIDs = append(search.IssueIDs, issueID)
+			}
+		}
+		// Issues returned without page are kept after the ordered ones
+		for _, issueID := range slices.Sorted(maps.Keys(issues)) {
+			if !slices.Contains(search.IssueIDs, issueID) {
+				cache.Issues[issueID] = cachedIssue{issues[issueID], search.FetchedAt}
+				search.IssueIDs = append(search.IssueIDs, issueID)
+			}
+		}
+		cache.Searches[key] = search
+	})
+
+	return issues, nil
+}
+
+func (tracker *CachedTracker) GetIssue(issueKeyID string) (*Issue, error) {
+	cached, ok := tracker.load().Issues[issueKeyID]
+
+	if tracker.mode != CacheModeRefresh && ok && (tracker.mode == CacheModeOffline || tracker.isFresh(cached.FetchedAt)) {
+		tracker.logger.Debug("🗃️ Issue %s served from cache (%s old)", issueKeyID, time.Since(cached.FetchedAt).Round(time.Second).String())
+		return cached.Issue, nil
+	}
+	if tracker.mode == CacheModeOffline {
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, ErrOffline)
+	}
+
+	currentIssue, err := tracker.tracker.GetIssue(issueKeyID)
+	if err != nil {
+		if ok && errors.Is(err, ErrNetwork) {
+			tracker.logger.Warn("⚠️ Tracker unreachable, showing issue %s cached %s ago", issueKeyID, time.Since(cached.FetchedAt).Round(time.Minute).String())
+			return cached.Issue, nil
+		}
+		return nil, err
+	}
+
+	tracker.update(func(cache *jsonCache) {
+		cache.Issues[issueKeyID] = cachedIssue{currentIssue, time.Now()}
+	})
+
+	return currentIssue, nil
+}
+
+func (tracker *CachedTracker) CreateIssue(options CreateIssueOptions) (*Issue, error) {
+	if tracker.mode == CacheModeOffline {
+		return nil, ErrOffline
+	}
+
+	createdIssue, err := tracker.tracker.CreateIssue(options)
+	if err == nil {
+		tracker.invalidate(createdIssue.ID)
+	}
+
+	return createdIssue, err
+}
+
+func (tracker *CachedTracker) SelfAssignIssue(issueKeyID string) error {
+	if tracker.mode == CacheModeOffline {
+		return ErrOffline
+	}
+
+	return tracker.invalidateOnSuccess(issueKeyID, tracker.tracker.SelfAssignIssue(issueKeyID))
+}
+
+func (tracker *CachedTracker) GetTransitions(issueKeyID string) ([]Transition, error) {
+	if tracker.mode == CacheModeOffline {
+		return nil, ErrOffline
+	}
+
+	return tracker.tracker.GetTransitions(issueKeyID)
+}
+
+func (tracker *CachedTracker) TransitionIssue(issueKeyID string, transition Transition) error {
+	if tracker.mode == CacheModeOffline {
+		return ErrOffline
+	}
+
+	return tracker.invalidateOnSuccess(issueKeyID, tracker.tracker.TransitionIssue(issueKeyID, transition))
+}
+
+func (tracker *CachedTracker) AddLabel(issueKeyID string, label string) error {
+	if tracker.mode == CacheModeOffline {
+		return ErrOffline
+	}
+
+	return tracker.invalidateOnSuccess(issueKeyID, tracker.tracker.AddLabel(issueKeyID, label))
+}
+
+func (tracker *CachedTracker) GetComments(issueKeyID string) ([]Comment, error) {
+	if tracker.mode == CacheModeOffline {
+		return nil, ErrOffline
+	}
+
+	return tracker.tracker.GetComments(issueKeyID)
+}
+
+func (tracker *CachedTracker) AddComment(issueKeyID string, markdown string) (*Comment, error) {
+	if tracker.mode == CacheModeOffline {
+		return nil, ErrOffline
+	}
+
+	return tracker.tracker.AddComment(issueKeyID, markdown)
+}
+
+func (tracker *CachedTracker) AddWorklog(issueKeyID string, started time.Time, spent time.Duration, comment string) error {
+	if tracker.mode == CacheModeOffline {
+		return ErrOffline
+	}
+
+	return tracker.tracker.AddWorklog(issueKeyID, started, spent, comment)
+}
+
+func (tracker *CachedTracker) isFresh(fetchedAt time.Time) bool {
+	return time.Since(fetchedAt) < tracker.ttl
+}
+
+// getSearchKey identifies a search by a hash of its options, relative dates are identified by their raw value as they change on every run
+func (tracker *CachedTracker) getSearchKey(options SearchOptions) string {
+	if options.UpdatedSinceValue != "" {
+		options.UpdatedSince = time.Time{}
+	}
+	rawOptions, _ := json.Marshal(options)
+	hash := sha256.Sum256(rawOptions)
+	return hex.EncodeToString(hash[:])
+}
+
+// getSearch returns the cached issues of a search, by ID and in the order of the search, searches with evicted issues are ignored
+func (tracker *CachedTracker) getSearch(cache *jsonCache, key string) (map[string]*Issue, []*Issue, time.Time, bool) {
+	search, ok := cache.Searches[key]
+	if !ok {
+		return nil, nil, time.Time{}, false
+	}
+
+	issues := map[string]*Issue{}
+	orderedIssues := []*Issue{}
+	for _, issueID := range search.IssueIDs {
+		cached, ok := cache.Issues[issueID]
+		if !ok {
+			return nil, nil, time.Time{}, false
+		}
+		issues[issueID] = cached.Issue
+		orderedIssues = append(orderedIssues, cached.Issue)
+	}
+
+	return issues, orderedIssues, search.FetchedAt, true
+}
+
+func (tracker *CachedTracker) invalidateOnSuccess(issueKeyID string, err error) error {
+	if err == nil {
+		tracker.invalidate(issueKeyID)
+	}
+
+	return err
+}
+
+// invalidate removes an updated issue and every search, the search results may have changed
+func (tracker *CachedTracker) invalidate(issueKeyID string) {
+	tracker.update(func(cache *jsonCache) {
+		delete(cache.Issues, issueKeyID)
+		cache.Searches = map[string]cachedSearch{}
+	})
+}
+
+// load reads the cache, an unreadable cache is ignored as it only holds copies of tracker data
+func (tracker *CachedTracker) load() *jsonCache {
+	tracker.store.mutex.Lock()
+	defer tracker.store.mutex.Unlock()
+
+	return tracker.read()
+}
+
+func (tracker *CachedTracker) read() *jsonCache {
+	cache := &jsonCache{}
+	rawFileContent, err := os.ReadFile(tracker.store.path)
+	if err == nil {
+		if err := json.Unmarshal(rawFileContent, cache); err != nil {
+			tracker.logger.Debug("Ignoring invalid cache file %s: %v", tracker.store.path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		tracker.logger.Debug("Unable to read cache file %s: %v", tracker.store.path, err)
+	}
+
+	if cache.Issues == nil {
+		cache.Issues = map[string]cachedIssue{}
+	}
+	if cache.Searches == nil {
+		cache.Searches = map[string]cachedSearch{}
+	}
+
+	return cache
+}
+
+// update applies changes to the cache file and drops the entries older than the retention, failures are only logged as the tracker data is still returned
+func (tracker *CachedTracker) update(apply func(cache *jsonCache)) {
+	tracker.store.mutex.Lock()
+	defer tracker.store.mutex.Unlock()
+
+	cache := tracker.read()
+	apply(cache)
+	maps.DeleteFunc(cache.Issues, func(_ string, cached cachedIssue) bool {
+		return time.Since(cached.FetchedAt) > cacheRetention
+	})
+	maps.DeleteFunc(cache.Searches, func(_ string, search cachedSearch) bool {
+		return time.Since(search.FetchedAt) > cacheRetention
+	})
+
+	if err := tracker.write(cache); err != nil {
+		tracker.logger.Debug("Unable to write cache file %s: %v", tracker.store.path, err)
+	}
+}
+
+// write replaces the cache file through a temporary file, other gira processes never read a partial cache
+func (tracker *CachedTracker) write(cache *jsonCache) error {
+	rawFileContent, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(tracker.store.path), 0o700); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(tracker.store.path), filepath.Base(tracker.store.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(rawFileContent); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), tracker.store.path)
+}
@@ -0,0 +1,275 @@
+package issue
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Ealenn/gira/internal/log"
+)
+
+// fakeTracker serves pages of issues, and fails with err when set
+type fakeTracker struct {
+	Tracker
+	pages    [][]*Issue
+	err      error
+	searches int
+	gets     int
+	scope    string
+}
+
+func (tracker *fakeTracker) SearchIssues(_ SearchOptions, onPage PageHandler) (map[string]*Issue, error) {
+	tracker.searches++
+	if tracker.err != nil {
+		return nil, tracker.err
+	}
+
+	issues := map[string]*Issue{}
+	for _, page := range tracker.pages {
+		for _, currentIssue := range page {
+			issues[currentIssue.ID] = currentIssue
+		}
+		notifyPage(onPage, page)
+	}
+
+	return issues, nil
+}
+
+func (tracker *fakeTracker) GetIssue(issueKeyID string) (*Issue, error) {
+	tracker.gets++
+	if tracker.err != nil {
+		return nil, tracker.err
+	}
+
+	return &Issue{ID: issueKeyID, Title: "fetched"}, nil
+}
+
+func (tracker *fakeTracker) AddLabel(_ string, _ string) error {
+	return tracker.err
+}
+
+func (tracker *fakeTracker) Scope() string {
+	return tracker.scope
+}
+
+func newTestCachedTracker(t *testing.T, tracker Tracker, ttl time.Duration, mode CacheMode) *CachedTracker {
+	t.Helper()
+	verbose := false
+	return &CachedTracker{log.New(&verbose), tracker, &cacheStore{path: filepath.Join(t.TempDir(), "cache.json")}, ttl, mode}
+}
+
+// withMode returns a tracker sharing the cache file, like the trackers of successive commands
+func withMode(cachedTracker *CachedTracker, tracker Tracker, ttl time.Duration, mode CacheMode) *CachedTracker {
+	return &CachedTracker{cachedTracker.logger, tracker, cachedTracker.store, ttl, mode}
+}
+
+func search(t *testing.T, tracker Tracker, options SearchOptions) ([]string, error) {
+	t.Helper()
+	issueIDs := []string{}
+	_, err := tracker.SearchIssues(options, func(issues []*Issue) {
+		for _, currentIssue := range issues {
+			issueIDs = append(issueIDs, currentIssue.ID)
+		}
+	})
+
+	return issueIDs, err
+}
+
+func testPages() [][]*Issue {
+	return [][]*Issue{
+		{{ID: "ABC-3"}, {ID: "ABC-1"}},
+		{{ID: "ABC-10"}, {ID: "ABC-2"}},
+	}
+}
+
+func TestCachedTrackerSearchKeepsOrder(t *testing.T) {
+	want := []string{"ABC-3", "ABC-1", "ABC-10", "ABC-2"}
+	tracker := &fakeTracker{pages: testPages()}
+	cachedTracker := newTestCachedTracker(t, tracker, time.Hour, CacheModeDefault)
+
+	tests := []struct {
+		name          string
+		cachedTracker *CachedTracker
+		wantSearches  int
+	}{
+		{"from the tracker", cachedTracker, 1},
+		{"from the cache", cachedTracker, 1},
+		{"offline", withMode(cachedTracker, tracker, time.Hour, CacheModeOffline), 1},
+		{"stale, tracker unreachable", withMode(cachedTracker, &fakeTracker{err: ErrNetwork}, 0, CacheModeDefault), 1},
+		{"refresh", withMode(cachedTracker, tracker, time.Hour, CacheModeRefresh), 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			issueIDs, err := search(t, test.cachedTracker, SearchOptions{Sort: "updated"})
+			if err != nil {
+				t.Fatalf("SearchIssues() returned %v", err)
+			}
+			if !slices.Equal(issueIDs, want) {
+				t.Errorf("pages = %v, want %v", issueIDs, want)
+			}
+			if tracker.searches != test.wantSearches {
+				t.Errorf("tracker searched %d times, want %d", tracker.searches, test.wantSearches)
+			}
+		})
+	}
+}
+
+func TestCachedTrackerSearchErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    CacheMode
+		err     error
+		wantErr error
+	}{
+		{"offline without cache", CacheModeOffline, nil, ErrOffline},
+		{"unreachable without cache", CacheModeDefault, ErrNetwork, ErrNetwork},
+		{"unauthorized", CacheModeDefault, ErrUnauthorized, ErrUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cachedTracker := newTestCachedTracker(t, &fakeTracker{err: test.err}, time.Hour, test.mode)
+			if _, err := search(t, cachedTracker, SearchOptions{}); !errors.Is(err, test.wantErr) {
+				t.Errorf("SearchIssues() returned %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCachedTrackerSearchKey(t *testing.T) {
+	cachedTracker := newTestCachedTracker(t, &fakeTracker{}, time.Hour, CacheModeDefault)
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		a, b  SearchOptions
+		equal bool
+	}{
+		{"same options", SearchOptions{Status: "open"}, SearchOptions{Status: "open"}, true},
+		{"different status", SearchOptions{Status: "open"}, SearchOptions{Status: "closed"}, false},
+		{"different sort", SearchOptions{Sort: "created"}, SearchOptions{Sort: "updated"}, false},
+		{
+			"relative date on successive runs",
+			SearchOptions{UpdatedSince: now.AddDate(0, 0, -7), UpdatedSinceValue: "7d"},
+			SearchOptions{UpdatedSince: now.Add(time.Minute).AddDate(0, 0, -7), UpdatedSinceValue: "7d"},
+			true,
+		},
+		{
+			"different relative dates",
+			SearchOptions{UpdatedSince: now.AddDate(0, 0, -7), UpdatedSinceValue: "7d"},
+			SearchOptions{UpdatedSince: now.AddDate(0, 0, -7), UpdatedSinceValue: "1w"},
+			false,
+		},
+		{"different dates without value", SearchOptions{UpdatedSince: now}, SearchOptions{UpdatedSince: now.Add(time.Minute)}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if equal := cachedTracker.getSearchKey(test.a) == cachedTracker.getSearchKey(test.b); equal != test.equal {
+				t.Errorf("same key = %t, want %t", equal, test.equal)
+			}
+		})
+	}
+}
+
+func TestCachedTrackerDropsExpiredEntries(t *testing.T) {
+	cachedTracker := newTestCachedTracker(t, &fakeTracker{}, time.Hour, CacheModeDefault)
+	expired := time.Now().Add(-cacheRetention - time.Hour)
+
+	cachedTracker.update(func(cache *jsonCache) {
+		cache.Issues["ABC-1"] = cachedIssue{&Issue{ID: "ABC-1"}, expired}
+		cache.Issues["ABC-2"] = cachedIssue{&Issue{ID: "ABC-2"}, time.Now()}
+		cache.Searches["expired"] = cachedSearch{[]string{"ABC-1"}, expired}
+		cache.Searches["recent"] = cachedSearch{[]string{"ABC-2"}, time.Now()}
+	})
+
+	cache := cachedTracker.load()
+	if _, ok := cache.Issues["ABC-1"]; ok {
+		t.Error("expired issue kept")
+	}
+	if _, ok := cache.Searches["expired"]; ok {
+		t.Error("expired search kept")
+	}
+	if _, ok := cache.Issues["ABC-2"]; !ok {
+		t.Error("recent issue dropped")
+	}
+	if _, ok := cache.Searches["recent"]; !ok {
+		t.Error("recent search dropped")
+	}
+}
+
+func TestCachedTrackerGetIssue(t *testing.T) {
+	tracker := &fakeTracker{}
+	cachedTracker := newTestCachedTracker(t, tracker, time.Hour, CacheModeDefault)
+
+	for range 2 {
+		if _, err := cachedTracker.GetIssue("ABC-1"); err != nil {
+			t.Fatalf("GetIssue() returned %v", err)
+		}
+	}
+	if tracker.gets != 1 {
+		t.Errorf("tracker fetched the issue %d times, want 1", tracker.gets)
+	}
+
+	// Updates invalidate the issue and the searches
+	if _, err := search(t, cachedTracker, SearchOptions{}); err != nil {
+		t.Fatalf("SearchIssues() returned %v", err)
+	}
+	if err := cachedTracker.AddLabel("ABC-1", "backend"); err != nil {
+		t.Fatalf("AddLabel() returned %v", err)
+	}
+	if _, err := cachedTracker.GetIssue("ABC-1"); err != nil {
+		t.Fatalf("GetIssue() returned %v", err)
+	}
+	if tracker.gets != 2 {
+		t.Errorf("tracker fetched the issue %d times after an update, want 2", tracker.gets)
+	}
+	if searches := len(cachedTracker.load().Searches); searches != 0 {
+		t.Errorf("%d searches kept after an update, want 0", searches)
+	}
+
+	offlineTracker := withMode(cachedTracker, tracker, time.Hour, CacheModeOffline)
+	if _, err := offlineTracker.GetIssue("ABC-404"); !errors.Is(err, ErrOffline) || !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetIssue() offline returned %v, want %v and %v", err, ErrOffline, ErrNotFound)
+	}
+	if err := offlineTracker.AddLabel("ABC-1", "backend"); !errors.Is(err, ErrOffline) {
+		t.Errorf("AddLabel() offline returned %v, want %v", err, ErrOffline)
+	}
+}
+
+func TestGetCacheFileName(t *testing.T) {
+	verbose := false
+	logger := log.New(&verbose)
+
+	first := getCacheFileName(logger, &fakeTracker{scope: "github.com/acme/first"}, "work")
+	second := getCacheFileName(logger, &fakeTracker{scope: "github.com/acme/second"}, "work")
+	if first == second {
+		t.Errorf("repositories share the cache file %s", first)
+	}
+	if again := getCacheFileName(logger, &fakeTracker{scope: "github.com/acme/first"}, "work"); again != first {
+		t.Errorf("cache file = %s, want %s", again, first)
+	}
+
+	type unscopedTracker struct{ Tracker }
+	if fileName := getCacheFileName(logger, unscopedTracker{}, "work"); fileName != "work.json" {
+		t.Errorf("cache file = %s, want work.json", fileName)
+	}
+}
+
+func TestNewCachedTrackerWithoutCacheDir(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the user cache dir is only read from the environment on Linux")
+	}
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv("HOME", "")
+
+	verbose := false
+	tracker := &fakeTracker{}
+	if cachedTracker := NewCachedTracker(log.New(&verbose), tracker, "work", time.Hour, CacheModeDefault); cachedTracker != tracker {
+		t.Errorf("NewCachedTracker() = %T, want the tracker without cache", cachedTracker)
+	}
+}
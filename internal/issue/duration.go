@@ -0,0 +1,25 @@
+package issue
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FormatDuration formats a worklog duration like Jira does (e.g. "1h 30m"), rounded to the minute
+func FormatDuration(duration time.Duration) string {
+	minutes := int(duration.Round(time.Minute).Minutes())
+	if minutes < 1 {
+		return "0m"
+	}
+
+	parts := []string{}
+	if hours := minutes / 60; hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes%60 > 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes%60))
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,78 @@
+package issue
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+var (
+	ErrNotFound     = errors.New("issue not found")
+	ErrUnauthorized = errors.New("unauthorized, check your token")
+	ErrRateLimited  = errors.New("rate limited by the tracker API")
+	ErrNetwork      = errors.New("unable to reach the tracker")
+)
+
+// Exit codes returned by commands, so scripts can react to each failure category
+const (
+	ExitCodeError        = 1
+	ExitCodeNotFound     = 3
+	ExitCodeUnauthorized = 4
+	ExitCodeRateLimited  = 5
+	ExitCodeNetwork      = 6
+	ExitCodeOffline      = 7
+)
+
+func ExitCode(err error) int {
+	switch {
+	case errors.Is(err, ErrOffline):
+		return ExitCodeOffline
+	case errors.Is(err, ErrNotFound):
+		return ExitCodeNotFound
+	case errors.Is(err, ErrUnauthorized):
+		return ExitCodeUnauthorized
+	case errors.Is(err, ErrRateLimited):
+		return ExitCodeRateLimited
+	case errors.Is(err, ErrNetwork):
+		return ExitCodeNetwork
+	default:
+		return ExitCodeError
+	}
+}
+
+// Reason returns a short human readable cause of the error category
+func Reason(err error) string {
+	for _, category := range []error{ErrOffline, ErrNotFound, ErrUnauthorized, ErrRateLimited, ErrNetwork} {
+		if errors.Is(err, category) {
+			return category.Error()
+		}
+	}
+
+	return err.Error()
+}
+
+// wrapError classifies an API error using the HTTP status code of the response (0 when no response was received)
+func wrapError(statusCode int, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %w", ErrRateLimited, err)
+	}
+
+	var urlError *url.Error
+	var netError net.Error
+	if statusCode == 0 && (errors.As(err, &urlError) || errors.As(err, &netError)) {
+		return fmt.Errorf("%w: %w", ErrNetwork, err)
+	}
+
+	return err
+}
@@ -2,12 +2,17 @@ package issue
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Ealenn/gira/internal/configuration"
 	"github.com/Ealenn/gira/internal/git"
 	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/network"
 
 	"github.com/google/go-github/v73/github"
 )
@@ -20,7 +25,22 @@ type GitHubTracker struct {
 }
 
 func NewGitHub(logger *log.Logger, profile *configuration.Profile, git *git.Git) *GitHubTracker {
-	client := github.NewClient(nil)
+	httpClient, err := network.NewClient(logger, profile.TLS)
+	if err != nil {
+		logger.Debug("HTTP client error: %v", err)
+		logger.Fatal("❌ Unable to configure TLS for %s", "GitHub")
+	}
+
+	client := github.NewClient(httpClient)
+
+	if profile.Github.BaseURL != "" {
+		enterpriseClient, err := client.WithEnterpriseURLs(profile.Github.BaseURL, profile.Github.BaseURL)
+		if err != nil {
+			logger.Debug("GitHub Enterprise client error: %v", err)
+			logger.Fatal("Unable to create GitHub Enterprise Client for %s", profile.Github.BaseURL)
+		}
+		client = enterpriseClient
+	}
 
 	if profile.Github.Token != "" {
 		client = client.WithAuthToken(profile.Github.Token)
@@ -34,42 +54,179 @@ func NewGitHub(logger *log.Logger, profile *configuration.Profile, git *git.Git)
 	}
 }
 
-func (tracker *GitHubTracker) SearchIssues(status string) map[string]*Issue {
-	username, repository := tracker.getCurrentRepository()
-	issues, response, err := tracker.githubClient.Issues.ListByRepo(context.Background(), username, repository, &github.IssueListByRepoOptions{
-		State: status,
-	})
+func (tracker *GitHubTracker) SearchIssues(options SearchOptions, onPage PageHandler) (map[string]*Issue, error) {
+	username, repository, err := tracker.getCurrentRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	// The Search API is limited to 30 requests per minute and 1000 results, it is only needed for text and assignee filters
+	if options.Query == "" && options.Assignee == "" {
+		return tracker.listIssues(username, repository, options, onPage)
+	}
+
+	query, err := tracker.toSearchQuery(username, repository, options)
+	if err != nil {
+		return nil, err
+	}
+	tracker.logger.Debug("Search issues with query %s", query)
+
+	maxResults := getMaxResults(tracker.profile)
+	searchOptions := &github.SearchOptions{
+		Sort:        options.Sort,
+		Order:       options.Order,
+		ListOptions: github.ListOptions{PerPage: searchPageSize},
+	}
+
+	filteredIssues := make(map[string]*Issue)
+	for len(filteredIssues) < maxResults {
+		result, response, err := tracker.githubClient.Search.Issues(context.Background(), query, searchOptions)
+
+		if err != nil {
+			tracker.logger.Debug("Search issues response status %d with error %v", tracker.statusCode(response), err)
+			return nil, tracker.wrapError(response, err)
+		}
+
+		pageIssues := []*Issue{}
+		for _, issue := range result.Issues {
+			if len(filteredIssues) < maxResults {
+				formattedIssue := tracker.formatIssue(issue)
+				filteredIssues[formattedIssue.ID] = formattedIssue
+				pageIssues = append(pageIssues, formattedIssue)
+			}
+		}
+		notifyPage(onPage, pageIssues)
+
+		tracker.logger.Debug("Search issues page %d loaded, next page %d", searchOptions.Page, response.NextPage)
+		if response.NextPage == 0 {
+			break
+		}
+		searchOptions.Page = response.NextPage
+	}
+
+	return filteredIssues, nil
+}
 
+// listIssues lists the issues of the repository, types are labels
+func (tracker *GitHubTracker) listIssues(username string, repository string, searchOptions SearchOptions, onPage PageHandler) (map[string]*Issue, error) {
+	state, err := tracker.getState(searchOptions.Status)
 	if err != nil {
-		tracker.logger.Debug("Search issues response status %s with error %v", response.Status, err)
-		tracker.logger.Fatal("❌ Unable to find issues")
+		return nil, err
+	}
+
+	maxResults := getMaxResults(tracker.profile)
+	options := &github.IssueListByRepoOptions{
+		State:       state,
+		Labels:      searchOptions.Types,
+		Since:       searchOptions.UpdatedSince,
+		Sort:        searchOptions.Sort,
+		Direction:   searchOptions.Order,
+		ListOptions: github.ListOptions{PerPage: searchPageSize},
 	}
 
 	filteredIssues := make(map[string]*Issue)
-	for _, issue := range issues {
-		if !issue.IsPullRequest() {
-			filteredIssues[tracker.getIssueString(issue.GetNumber())] = tracker.formatIssue(issue)
+	for len(filteredIssues) < maxResults {
+		issues, response, err := tracker.githubClient.Issues.ListByRepo(context.Background(), username, repository, options)
+
+		if err != nil {
+			tracker.logger.Debug("List issues response status %d with error %v", tracker.statusCode(response), err)
+			return nil, tracker.wrapError(response, err)
+		}
+
+		// Pull requests are listed with issues
+		pageIssues := []*Issue{}
+		for _, issue := range issues {
+			if !issue.IsPullRequest() && len(filteredIssues) < maxResults {
+				formattedIssue := tracker.formatIssue(issue)
+				filteredIssues[formattedIssue.ID] = formattedIssue
+				pageIssues = append(pageIssues, formattedIssue)
+			}
+		}
+		notifyPage(onPage, pageIssues)
+
+		tracker.logger.Debug("List issues page %d loaded, next page %d", options.ListOptions.Page, response.NextPage)
+		if response.NextPage == 0 {
+			break
 		}
+		options.ListOptions.Page = response.NextPage
 	}
 
-	return filteredIssues
+	return filteredIssues, nil
 }
 
-func (tracker *GitHubTracker) GetIssue(issueKeyID string) *Issue {
-	issueNumber := tracker.getIssueNumber(issueKeyID)
-	username, repository := tracker.getCurrentRepository()
+// getState returns the GitHub state of the status, every issue when it is empty
+func (tracker *GitHubTracker) getState(status string) (string, error) {
+	switch status = strings.ToLower(status); status {
+	case "", "all":
+		return "all", nil
+	case "open", "closed":
+		return status, nil
+	default:
+		return "", fmt.Errorf("unsupported GitHub status %s, expected open, closed or all", status)
+	}
+}
+
+// toSearchQuery builds a GitHub search query, types are labels
+func (tracker *GitHubTracker) toSearchQuery(username string, repository string, options SearchOptions) (string, error) {
+	qualifiers := []string{"repo:" + username + "/" + repository, "is:issue"}
+
+	state, err := tracker.getState(options.Status)
+	if err != nil {
+		return "", err
+	}
+	if state != "all" {
+		qualifiers = append(qualifiers, "state:"+state)
+	}
+
+	switch options.Assignee {
+	case "":
+	case AssigneeMe:
+		qualifiers = append(qualifiers, "assignee:@me")
+	default:
+		qualifiers = append(qualifiers, "assignee:"+options.Assignee)
+	}
+
+	for _, label := range options.Types {
+		qualifiers = append(qualifiers, fmt.Sprintf("label:%q", label))
+	}
+
+	if !options.UpdatedSince.IsZero() {
+		qualifiers = append(qualifiers, "updated:>="+options.UpdatedSince.Format("2006-01-02"))
+	}
+
+	if options.Query != "" {
+		qualifiers = append(qualifiers, options.Query)
+	}
+
+	return strings.Join(qualifiers, " "), nil
+}
+
+func (tracker *GitHubTracker) GetIssue(issueKeyID string) (*Issue, error) {
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	username, repository, err := tracker.getCurrentRepository()
+	if err != nil {
+		return nil, err
+	}
+
 	issue, issueResponse, err := tracker.githubClient.Issues.Get(context.Background(), username, repository, issueNumber)
 
 	if err != nil {
-		tracker.logger.Debug("Issue %s response status %s with error %v", issueKeyID, issueResponse.Status, err)
-		tracker.logger.Fatal("❌ Unable to find issue %s", issueKeyID)
+		tracker.logger.Debug("Issue %s response status %d with error %v", issueKeyID, tracker.statusCode(issueResponse), err)
+		return nil, tracker.wrapError(issueResponse, err)
 	}
 
-	return tracker.formatIssue(issue)
+	return tracker.formatIssue(issue), nil
 }
 
-func (tracker *GitHubTracker) CreateIssue(options CreateIssueOptions) *Issue {
-	username, repository := tracker.getCurrentRepository()
+func (tracker *GitHubTracker) CreateIssue(options CreateIssueOptions) (*Issue, error) {
+	username, repository, err := tracker.getCurrentRepository()
+	if err != nil {
+		return nil, err
+	}
 
 	issue, response, err := tracker.githubClient.Issues.Create(context.Background(), username, repository, &github.IssueRequest{
 		Title: &options.Title,
@@ -77,53 +234,297 @@ func (tracker *GitHubTracker) CreateIssue(options CreateIssueOptions) *Issue {
 	})
 
 	if err != nil {
-		tracker.logger.Fatal("Unable to create Github issue due to %s", response.Status)
+		tracker.logger.Debug("Create issue response status %d with error %v", tracker.statusCode(response), err)
+		return nil, tracker.wrapError(response, err)
 	}
 
 	tracker.logger.Debug("Issue %v created", issue.GetNumber())
-	return tracker.formatIssue(issue)
+	return tracker.formatIssue(issue), nil
+}
+
+// Scope identifies the repository, issue numbers are only unique in a repository
+func (tracker *GitHubTracker) Scope() string {
+	owner, repository, err := tracker.getCurrentRepository()
+	if err != nil {
+		return ""
+	}
+
+	return tracker.getHost() + "/" + owner + "/" + repository
+}
+
+// Authenticate returns the login of the token owner and the scopes of classic tokens, fine-grained tokens don't list them
+func (tracker *GitHubTracker) Authenticate() (string, []string, error) {
+	user, response, err := tracker.githubClient.Users.Get(context.Background(), "")
+	if err != nil {
+		tracker.logger.Debug("Authenticated user response status %d with error %v", tracker.statusCode(response), err)
+		return "", nil, tracker.wrapError(response, err)
+	}
+
+	var scopes []string
+	if values := response.Header.Values("X-OAuth-Scopes"); len(values) > 0 {
+		scopes = []string{}
+		for _, scope := range strings.Split(values[0], ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+
+	return user.GetLogin(), scopes, nil
 }
 
 func (tracker *GitHubTracker) SelfAssignIssue(issueKeyID string) error {
-	issueNumber := tracker.getIssueNumber(issueKeyID)
-	username, repository := tracker.getCurrentRepository()
-	_, issueResponse, err := tracker.githubClient.Issues.AddAssignees(context.Background(), username, repository, issueNumber, []string{tracker.profile.Github.User})
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return err
+	}
 
+	username, repository, err := tracker.getCurrentRepository()
 	if err != nil {
-		tracker.logger.Debug("Unable to fetch Github release due to %s", issueResponse.Status)
 		return err
 	}
 
+	_, issueResponse, err := tracker.githubClient.Issues.AddAssignees(context.Background(), username, repository, issueNumber, []string{tracker.profile.Github.User})
+
+	if err != nil {
+		tracker.logger.Debug("Assign issue response status %d with error %v", tracker.statusCode(issueResponse), err)
+		return tracker.wrapError(issueResponse, err)
+	}
+
 	return nil
 }
 
-func (tracker *GitHubTracker) getCurrentRepository() (string, string) {
-	origin := tracker.git.CurrentOrigin()
+func (tracker *GitHubTracker) AddLabel(issueKeyID string, label string) error {
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return err
+	}
+
+	username, repository, err := tracker.getCurrentRepository()
+	if err != nil {
+		return err
+	}
+
+	_, labelResponse, err := tracker.githubClient.Issues.AddLabelsToIssue(context.Background(), username, repository, issueNumber, []string{label})
+	if err != nil {
+		tracker.logger.Debug("Add label to %s response status %d with error %v", issueKeyID, tracker.statusCode(labelResponse), err)
+	}
+
+	return tracker.wrapError(labelResponse, err)
+}
+
+func (tracker *GitHubTracker) GetTransitions(issueKeyID string) ([]Transition, error) {
+	issue, err := tracker.GetIssue(issueKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// GitHub issues only know two states, transitions are close (with a reason) or reopen
+	if issue.Status == "closed" {
+		return []Transition{
+			{ID: "reopened", Name: "Reopen", Status: "open"},
+		}, nil
+	}
+
+	return []Transition{
+		{ID: "completed", Name: "Close as completed", Status: "closed"},
+		{ID: "not_planned", Name: "Close as not planned", Status: "closed"},
+	}, nil
+}
+
+func (tracker *GitHubTracker) TransitionIssue(issueKeyID string, transition Transition) error {
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return err
+	}
+
+	username, repository, err := tracker.getCurrentRepository()
+	if err != nil {
+		return err
+	}
+
+	_, issueResponse, err := tracker.githubClient.Issues.Edit(context.Background(), username, repository, issueNumber, &github.IssueRequest{
+		State:       &transition.Status,
+		StateReason: &transition.ID,
+	})
+
+	if err != nil {
+		tracker.logger.Debug("Move issue %s to %s response status %d", issueKeyID, transition.Status, tracker.statusCode(issueResponse))
+	}
+
+	return tracker.wrapError(issueResponse, err)
+}
+
+func (tracker *GitHubTracker) GetComments(issueKeyID string) ([]Comment, error) {
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	username, repository, err := tracker.getCurrentRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	options := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: searchPageSize},
+	}
+
+	comments := []Comment{}
+	for {
+		githubComments, response, err := tracker.githubClient.Issues.ListComments(context.Background(), username, repository, issueNumber, options)
+		if err != nil {
+			tracker.logger.Debug("Comments of %s response status %d with error %v", issueKeyID, tracker.statusCode(response), err)
+			return nil, tracker.wrapError(response, err)
+		}
+
+		for _, comment := range githubComments {
+			comments = append(comments, tracker.formatComment(comment))
+		}
 
-	if !strings.HasPrefix(origin, "git@github.com:") || !strings.HasSuffix(origin, ".git") {
-		tracker.logger.Fatal("Invalid Git Remote Origin format")
+		if response.NextPage == 0 {
+			break
+		}
+		options.ListOptions.Page = response.NextPage
 	}
 
-	trimmed := strings.TrimPrefix(origin, "git@github.com:")
-	trimmed = strings.TrimSuffix(trimmed, ".git")
+	return comments, nil
+}
+
+func (tracker *GitHubTracker) AddComment(issueKeyID string, markdown string) (*Comment, error) {
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return nil, err
+	}
 
-	parts := strings.Split(trimmed, "/")
-	if len(parts) != 2 {
-		tracker.logger.Fatal("Invalid Git Remote Origin URL")
+	username, repository, err := tracker.getCurrentRepository()
+	if err != nil {
+		return nil, err
 	}
 
-	username := parts[0]
-	repository := parts[1]
+	comment, response, err := tracker.githubClient.Issues.CreateComment(context.Background(), username, repository, issueNumber, &github.IssueComment{
+		Body: &markdown,
+	})
+	if err != nil {
+		tracker.logger.Debug("Add comment to %s response status %d with error %v", issueKeyID, tracker.statusCode(response), err)
+		return nil, tracker.wrapError(response, err)
+	}
 
-	return username, repository
+	formattedComment := tracker.formatComment(comment)
+	return &formattedComment, nil
 }
 
-func (tracker *GitHubTracker) getIssueNumber(issueKeyID string) int {
+// AddWorklog records the time spent as a comment, GitHub issues have no time tracking
+func (tracker *GitHubTracker) AddWorklog(issueKeyID string, started time.Time, spent time.Duration, comment string) error {
+	body := fmt.Sprintf("⏱️ Spent **%s** (started %s)", FormatDuration(spent), started.Format(time.RFC822))
+	if comment != "" {
+		body += "\n\n" + comment
+	}
+
+	_, err := tracker.AddComment(issueKeyID, body)
+	return err
+}
+
+func (tracker *GitHubTracker) GetDefaultBranch() (string, error) {
+	username, repository, err := tracker.getCurrentRepository()
+	if err != nil {
+		return "", err
+	}
+
+	githubRepository, response, err := tracker.githubClient.Repositories.Get(context.Background(), username, repository)
+	if err != nil {
+		tracker.logger.Debug("Repository %s/%s response status %d with error %v", username, repository, tracker.statusCode(response), err)
+		return "", tracker.wrapError(response, err)
+	}
+
+	return githubRepository.GetDefaultBranch(), nil
+}
+
+func (tracker *GitHubTracker) CreatePullRequest(options PullRequestOptions) (*PullRequest, error) {
+	username, repository, err := tracker.getCurrentRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	pullRequest, response, err := tracker.githubClient.PullRequests.Create(context.Background(), username, repository, &github.NewPullRequest{
+		Title: &options.Title,
+		Body:  &options.Body,
+		Head:  &options.Head,
+		Base:  &options.Base,
+		Draft: &options.Draft,
+	})
+
+	if err != nil {
+		tracker.logger.Debug("Create pull request response status %d with error %v", tracker.statusCode(response), err)
+		return nil, tracker.wrapError(response, err)
+	}
+
+	tracker.logger.Debug("Pull request %v created", pullRequest.GetNumber())
+	return &PullRequest{
+		ID:  tracker.getIssueString(pullRequest.GetNumber()),
+		URL: pullRequest.GetHTMLURL(),
+	}, nil
+}
+
+func (tracker *GitHubTracker) getCurrentRepository() (string, string, error) {
+	// Explicit override, useful when the origin is a mirror
+	if tracker.profile.Github.Repository != "" {
+		owner, repository, _ := strings.Cut(tracker.profile.Github.Repository, "/")
+		return owner, repository, nil
+	}
+
+	remote, err := tracker.git.CurrentRemote()
+	if err != nil {
+		return "", "", err
+	}
+
+	if host := tracker.getHost(); remote.Host != host && !strings.HasSuffix(remote.Host, "."+host) {
+		return "", "", fmt.Errorf("git remote origin host %s doesn't match GitHub host %s, set the repository of the profile to override it", remote.Host, host)
+	}
+
+	if strings.Contains(remote.Owner, "/") {
+		return "", "", fmt.Errorf("invalid Git Remote Origin URL %s/%s, expected owner/repository", remote.Owner, remote.Repository)
+	}
+
+	return remote.Owner, remote.Repository, nil
+}
+
+func (tracker *GitHubTracker) getHost() string {
+	if tracker.profile.Github.BaseURL == "" {
+		return "github.com"
+	}
+
+	parsedBaseURL, err := url.Parse(tracker.profile.Github.BaseURL)
+	if err != nil {
+		return tracker.profile.Github.BaseURL
+	}
+	return strings.ToLower(parsedBaseURL.Hostname())
+}
+
+func (tracker *GitHubTracker) getIssueNumber(issueKeyID string) (int, error) {
 	issueNumber, err := strconv.Atoi(issueKeyID)
 	if err != nil {
-		tracker.logger.Fatal("Github issue ID %s invalid !", issueKeyID)
+		return 0, fmt.Errorf("invalid GitHub issue ID %s, expected a number", issueKeyID)
+	}
+	return issueNumber, nil
+}
+
+func (tracker *GitHubTracker) statusCode(response *github.Response) int {
+	if response == nil || response.Response == nil {
+		return 0
 	}
-	return issueNumber
+	return response.StatusCode
+}
+
+func (tracker *GitHubTracker) wrapError(response *github.Response, err error) error {
+	// GitHub answers 403 when the rate limit is exceeded
+	var rateLimitError *github.RateLimitError
+	var abuseRateLimitError *github.AbuseRateLimitError
+	if errors.As(err, &rateLimitError) || errors.As(err, &abuseRateLimitError) {
+		return fmt.Errorf("%w: %w", ErrRateLimited, err)
+	}
+
+	return wrapError(tracker.statusCode(response), err)
 }
 
 func (tracker *GitHubTracker) getIssueString(issueKeyID int) string {
@@ -162,3 +563,12 @@ func (tracker *GitHubTracker) formatIssue(issue *github.Issue) *Issue {
 		CreatedAt:   issue.CreatedAt.Time,
 	}
 }
+
+func (tracker *GitHubTracker) formatComment(comment *github.IssueComment) Comment {
+	return Comment{
+		ID:        strconv.FormatInt(comment.GetID(), 10),
+		Author:    comment.GetUser().GetLogin(),
+		Body:      comment.GetBody(),
+		CreatedAt: comment.GetCreatedAt().Time,
+	}
+}
@@ -0,0 +1,450 @@
+package issue
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ealenn/gira/internal/configuration"
+	"github.com/Ealenn/gira/internal/git"
+	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/network"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+type GitLabTracker struct {
+	logger       *log.Logger
+	profile      *configuration.Profile
+	git          *git.Git
+	gitlabClient *gitlab.Client
+}
+
+func NewGitLab(logger *log.Logger, profile *configuration.Profile, git *git.Git) *GitLabTracker {
+	httpClient, err := network.NewClient(logger, profile.TLS)
+	if err != nil {
+		logger.Debug("HTTP client error: %v", err)
+		logger.Fatal("❌ Unable to configure TLS for %s", profile.Gitlab.Host)
+	}
+
+	client, err := gitlab.NewClient(profile.Gitlab.Token, gitlab.WithBaseURL(profile.Gitlab.Host), gitlab.WithHTTPClient(httpClient))
+	if err != nil {
+		logger.Debug("GitLab client error: %v", err)
+		logger.Fatal("Unable to create GitLab Client")
+	}
+
+	return &GitLabTracker{
+		logger,
+		profile,
+		git,
+		client,
+	}
+}
+
+func (tracker *GitLabTracker) SearchIssues(searchOptions SearchOptions, onPage PageHandler) (map[string]*Issue, error) {
+	project, err := tracker.getCurrentProject()
+	if err != nil {
+		return nil, err
+	}
+
+	options := tracker.toListOptions(searchOptions)
+
+	maxResults := getMaxResults(tracker.profile)
+
+	filteredIssues := make(map[string]*Issue)
+	for len(filteredIssues) < maxResults {
+		issues, response, err := tracker.gitlabClient.Issues.ListProjectIssues(project, options)
+
+		if err != nil {
+			tracker.logger.Debug("Search issues response status %d with error %v", tracker.statusCode(response), err)
+			return nil, wrapError(tracker.statusCode(response), err)
+		}
+
+		pageIssues := []*Issue{}
+		for _, issue := range issues {
+			if len(filteredIssues) < maxResults {
+				formattedIssue := tracker.formatIssue(issue)
+				filteredIssues[formattedIssue.ID] = formattedIssue
+				pageIssues = append(pageIssues, formattedIssue)
+			}
+		}
+		notifyPage(onPage, pageIssues)
+
+		tracker.logger.Debug("Search issues page %d loaded, next page %d", options.Page, response.NextPage)
+		if response.NextPage == 0 {
+			break
+		}
+		options.Page = response.NextPage
+	}
+
+	return filteredIssues, nil
+}
+
+// toListOptions translates the search options, types are labels
+func (tracker *GitLabTracker) toListOptions(searchOptions SearchOptions) *gitlab.ListProjectIssuesOptions {
+	options := &gitlab.ListProjectIssuesOptions{
+		ListOptions: gitlab.ListOptions{PerPage: searchPageSize},
+	}
+
+	switch state := strings.ToLower(searchOptions.Status); state {
+	case "all", "":
+	case "open":
+		// GitLab names the open state "opened"
+		options.State = gitlab.Ptr("opened")
+	default:
+		options.State = &state
+	}
+
+	switch searchOptions.Assignee {
+	case "":
+	case AssigneeMe:
+		options.Scope = gitlab.Ptr("assigned_to_me")
+	default:
+		options.AssigneeUsername = &searchOptions.Assignee
+	}
+
+	if len(searchOptions.Types) > 0 {
+		options.Labels = (*gitlab.LabelOptions)(&searchOptions.Types)
+	}
+	if searchOptions.Query != "" {
+		options.Search = &searchOptions.Query
+	}
+	if !searchOptions.UpdatedSince.IsZero() {
+		options.UpdatedAfter = &searchOptions.UpdatedSince
+	}
+	if searchOptions.Sort != "" {
+		options.OrderBy = gitlab.Ptr(searchOptions.Sort + "_at")
+	}
+	if searchOptions.Order != "" {
+		options.Sort = &searchOptions.Order
+	}
+
+	return options
+}
+
+func (tracker *GitLabTracker) GetIssue(issueKeyID string) (*Issue, error) {
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := tracker.getCurrentProject()
+	if err != nil {
+		return nil, err
+	}
+
+	issue, issueResponse, err := tracker.gitlabClient.Issues.GetIssue(project, issueNumber)
+
+	if err != nil {
+		tracker.logger.Debug("Issue %s response status %d with error %v", issueKeyID, tracker.statusCode(issueResponse), err)
+		return nil, wrapError(tracker.statusCode(issueResponse), err)
+	}
+
+	return tracker.formatIssue(issue), nil
+}
+
+func (tracker *GitLabTracker) CreateIssue(options CreateIssueOptions) (*Issue, error) {
+	project, err := tracker.getCurrentProject()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := gitlab.LabelOptions{}
+	if options.Type == TypeBug {
+		labels = append(labels, "bug")
+	}
+
+	issue, response, err := tracker.gitlabClient.Issues.CreateIssue(project, &gitlab.CreateIssueOptions{
+		Title:       &options.Title,
+		Description: &options.Description,
+		Labels:      &labels,
+	})
+
+	if err != nil {
+		tracker.logger.Debug("Create issue response status %d with error %v", tracker.statusCode(response), err)
+		return nil, wrapError(tracker.statusCode(response), err)
+	}
+
+	tracker.logger.Debug("Issue %v created", issue.IID)
+	return tracker.formatIssue(issue), nil
+}
+
+// Scope identifies the project, issue numbers are only unique in a project
+func (tracker *GitLabTracker) Scope() string {
+	project, err := tracker.getCurrentProject()
+	if err != nil {
+		return ""
+	}
+
+	return tracker.profile.Gitlab.Host + "/" + project
+}
+
+// Authenticate returns the username of the token owner and the scopes of the token, nil when GitLab is older than 15.5
+func (tracker *GitLabTracker) Authenticate() (string, []string, error) {
+	user, userResponse, err := tracker.gitlabClient.Users.CurrentUser()
+	if err != nil {
+		tracker.logger.Debug("Current user response status %d with error %v", tracker.statusCode(userResponse), err)
+		return "", nil, wrapError(tracker.statusCode(userResponse), err)
+	}
+
+	token, tokenResponse, err := tracker.gitlabClient.PersonalAccessTokens.GetSinglePersonalAccessToken()
+	if err != nil {
+		tracker.logger.Debug("Token response status %d with error %v", tracker.statusCode(tokenResponse), err)
+		return user.Username, nil, nil
+	}
+
+	return user.Username, token.Scopes, nil
+}
+
+func (tracker *GitLabTracker) SelfAssignIssue(issueKeyID string) error {
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return err
+	}
+
+	project, err := tracker.getCurrentProject()
+	if err != nil {
+		return err
+	}
+
+	user, userResponse, err := tracker.gitlabClient.Users.CurrentUser()
+	if err != nil {
+		return wrapError(tracker.statusCode(userResponse), err)
+	}
+
+	_, issueResponse, err := tracker.gitlabClient.Issues.UpdateIssue(project, issueNumber, &gitlab.UpdateIssueOptions{
+		AssigneeIDs: &[]int{user.ID},
+	})
+
+	if err != nil {
+		tracker.logger.Debug("Assign issue response status %d with error %v", tracker.statusCode(issueResponse), err)
+		return wrapError(tracker.statusCode(issueResponse), err)
+	}
+
+	return nil
+}
+
+func (tracker *GitLabTracker) AddLabel(issueKeyID string, label string) error {
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return err
+	}
+
+	project, err := tracker.getCurrentProject()
+	if err != nil {
+		return err
+	}
+
+	_, issueResponse, err := tracker.gitlabClient.Issues.UpdateIssue(project, issueNumber, &gitlab.UpdateIssueOptions{
+		AddLabels: &gitlab.LabelOptions{label},
+	})
+	if err != nil {
+		tracker.logger.Debug("Add label to %s response status %d with error %v", issueKeyID, tracker.statusCode(issueResponse), err)
+	}
+
+	return wrapError(tracker.statusCode(issueResponse), err)
+}
+
+func (tracker *GitLabTracker) GetTransitions(issueKeyID string) ([]Transition, error) {
+	issue, err := tracker.GetIssue(issueKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// GitLab issues only know two states, transitions are close or reopen
+	if issue.Status == "closed" {
+		return []Transition{
+			{ID: "reopen", Name: "Reopen", Status: "opened"},
+		}, nil
+	}
+
+	return []Transition{
+		{ID: "close", Name: "Close", Status: "closed"},
+	}, nil
+}
+
+func (tracker *GitLabTracker) TransitionIssue(issueKeyID string, transition Transition) error {
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return err
+	}
+
+	project, err := tracker.getCurrentProject()
+	if err != nil {
+		return err
+	}
+
+	_, issueResponse, err := tracker.gitlabClient.Issues.UpdateIssue(project, issueNumber, &gitlab.UpdateIssueOptions{
+		StateEvent: &transition.ID,
+	})
+
+	if err != nil {
+		tracker.logger.Debug("Move issue %s to %s response status %d", issueKeyID, transition.Status, tracker.statusCode(issueResponse))
+	}
+
+	return wrapError(tracker.statusCode(issueResponse), err)
+}
+
+func (tracker *GitLabTracker) GetComments(issueKeyID string) ([]Comment, error) {
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := tracker.getCurrentProject()
+	if err != nil {
+		return nil, err
+	}
+
+	orderBy, sort := "created_at", "asc"
+	options := &gitlab.ListIssueNotesOptions{
+		ListOptions: gitlab.ListOptions{PerPage: searchPageSize},
+		OrderBy:     &orderBy,
+		Sort:        &sort,
+	}
+
+	comments := []Comment{}
+	for {
+		notes, response, err := tracker.gitlabClient.Notes.ListIssueNotes(project, issueNumber, options)
+		if err != nil {
+			tracker.logger.Debug("Comments of %s response status %d with error %v", issueKeyID, tracker.statusCode(response), err)
+			return nil, wrapError(tracker.statusCode(response), err)
+		}
+
+		for _, note := range notes {
+			// System notes are activity (e.g. "changed the description"), not comments
+			if note.System {
+				continue
+			}
+			comments = append(comments, tracker.formatComment(note))
+		}
+
+		if response.NextPage == 0 {
+			break
+		}
+		options.Page = response.NextPage
+	}
+
+	return comments, nil
+}
+
+func (tracker *GitLabTracker) AddComment(issueKeyID string, markdown string) (*Comment, error) {
+	issueNumber, err := tracker.getIssueNumber(issueKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := tracker.getCurrentProject()
+	if err != nil {
+		return nil, err
+	}
+
+	note, response, err := tracker.gitlabClient.Notes.CreateIssueNote(project, issueNumber, &gitlab.CreateIssueNoteOptions{
+		Body: &markdown,
+	})
+	if err != nil {
+		tracker.logger.Debug("Add comment to %s response status %d with error %v", issueKeyID, tracker.statusCode(response), err)
+		return nil, wrapError(tracker.statusCode(response), err)
+	}
+
+	comment := tracker.formatComment(note)
+	return &comment, nil
+}
+
+// AddWorklog uses the /spend quick action in a comment
+func (tracker *GitLabTracker) AddWorklog(issueKeyID string, started time.Time, spent time.Duration, comment string) error {
+	body := fmt.Sprintf("/spend %s %s", strings.ReplaceAll(FormatDuration(spent), " ", ""), started.Format("2006-01-02"))
+	if comment != "" {
+		body = comment + "\n\n" + body
+	}
+
+	_, err := tracker.AddComment(issueKeyID, body)
+	return err
+}
+
+func (tracker *GitLabTracker) getCurrentProject() (string, error) {
+	if tracker.profile.Gitlab.Project != "" {
+		return tracker.profile.Gitlab.Project, nil
+	}
+
+	remote, err := tracker.git.CurrentRemote()
+	if err != nil {
+		return "", err
+	}
+
+	host := tracker.profile.Gitlab.Host
+	if parsedHost, err := url.Parse(tracker.profile.Gitlab.Host); err == nil && parsedHost.Hostname() != "" {
+		host = strings.ToLower(parsedHost.Hostname())
+	}
+
+	if remote.Host != host && !strings.HasSuffix(remote.Host, "."+host) {
+		return "", fmt.Errorf("git remote origin host %s doesn't match GitLab host %s, set the project of the profile to override it", remote.Host, host)
+	}
+
+	// GitLab projects can be nested in subgroups (group/subgroup/project)
+	return remote.Path(), nil
+}
+
+func (tracker *GitLabTracker) getIssueNumber(issueKeyID string) (int, error) {
+	issueNumber, err := strconv.Atoi(issueKeyID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid GitLab issue ID %s, expected a number", issueKeyID)
+	}
+	return issueNumber, nil
+}
+
+func (tracker *GitLabTracker) statusCode(response *gitlab.Response) int {
+	if response == nil || response.Response == nil {
+		return 0
+	}
+	return response.StatusCode
+}
+
+func (tracker *GitLabTracker) getIssueString(issueKeyID int) string {
+	return strconv.Itoa(issueKeyID)
+}
+
+func (tracker *GitLabTracker) formatIssue(issue *gitlab.Issue) *Issue {
+	var assignees []Assignee
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, Assignee{
+			ID:    strconv.Itoa(assignee.ID),
+			Name:  assignee.Username,
+			Email: assignee.WebURL,
+		})
+	}
+
+	labels := []string{}
+	labels = append(labels, issue.Labels...)
+
+	formattedIssue := &Issue{
+		ID:          tracker.getIssueString(issue.IID),
+		Title:       issue.Title,
+		Description: issue.Description,
+		Status:      issue.State,
+		Types:       labels,
+		Assignees:   assignees,
+		URL:         issue.WebURL,
+	}
+
+	if issue.CreatedAt != nil {
+		formattedIssue.CreatedAt = *issue.CreatedAt
+	}
+
+	return formattedIssue
+}
+
+func (tracker *GitLabTracker) formatComment(note *gitlab.Note) Comment {
+	comment := Comment{
+		ID:     strconv.Itoa(note.ID),
+		Author: note.Author.Username,
+		Body:   note.Body,
+	}
+	if note.CreatedAt != nil {
+		comment.CreatedAt = *note.CreatedAt
+	}
+
+	return comment
+}
@@ -10,20 +10,48 @@ const (
 )
 
 type Assignee struct {
-	ID    string
-	Name  string
-	Email string
+	ID    string `json:"id" yaml:"id"`
+	Name  string `json:"name" yaml:"name"`
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
 }
 
 type Issue struct {
-	ID          string
-	Title       string
-	Description string
-	Status      string
-	Types       []string
-	Assignees   []Assignee
-	URL         string
-	CreatedAt   time.Time
+	ID          string     `json:"id" yaml:"id"`
+	Title       string     `json:"title" yaml:"title"`
+	Description string     `json:"description" yaml:"description"`
+	Status      string     `json:"status" yaml:"status"`
+	Priority    string     `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Types       []string   `json:"types" yaml:"types"`
+	Assignees   []Assignee `json:"assignees" yaml:"assignees"`
+	URL         string     `json:"url" yaml:"url"`
+	CreatedAt   time.Time  `json:"createdAt" yaml:"createdAt"`
+}
+
+type Transition struct {
+	ID     string
+	Name   string
+	Status string
+}
+
+type Comment struct {
+	ID        string
+	Author    string
+	Body      string
+	CreatedAt time.Time
+}
+
+// AssigneeMe searches the issues assigned to the authenticated user
+const AssigneeMe = "me"
+
+type SearchOptions struct {
+	Status            string // Status name, "open", "closed" or "all"
+	Assignee          string // Username, or AssigneeMe
+	Types             []string
+	Query             string
+	UpdatedSince      time.Time
+	UpdatedSinceValue string // Raw value of UpdatedSince (7d, 2025-01-31), identifies relative dates in the cache
+	Sort              string // "created" or "updated", tracker relevance when empty
+	Order             string // "asc" or "desc"
 }
 
 type CreateIssueOptions struct {
@@ -33,9 +61,46 @@ type CreateIssueOptions struct {
 	Project     string
 }
 
+type PullRequestOptions struct {
+	Title string
+	Body  string
+	Head  string
+	Base  string
+	Draft bool
+}
+
+type PullRequest struct {
+	ID  string
+	URL string
+}
+
 type Tracker interface {
-	SearchIssues(status string) map[string]*Issue
-	GetIssue(issueKeyID string) *Issue
-	CreateIssue(options CreateIssueOptions) *Issue
+	SearchIssues(options SearchOptions, onPage PageHandler) (map[string]*Issue, error)
+	GetIssue(issueKeyID string) (*Issue, error)
+	CreateIssue(options CreateIssueOptions) (*Issue, error)
 	SelfAssignIssue(issueKeyID string) error
+	GetTransitions(issueKeyID string) ([]Transition, error)
+	TransitionIssue(issueKeyID string, transition Transition) error
+	AddLabel(issueKeyID string, label string) error
+	GetComments(issueKeyID string) ([]Comment, error)
+	AddComment(issueKeyID string, markdown string) (*Comment, error)
+	AddWorklog(issueKeyID string, started time.Time, spent time.Duration, comment string) error
+}
+
+// PullRequestCreator is implemented by trackers hosting the Git repository
+type PullRequestCreator interface {
+	GetDefaultBranch() (string, error)
+	CreatePullRequest(options PullRequestOptions) (*PullRequest, error)
+}
+
+// Authenticator is implemented by trackers able to check the credentials of the profile
+type Authenticator interface {
+	// Authenticate returns the authenticated user, and the scopes of the token when the tracker lists them (nil otherwise)
+	Authenticate() (user string, scopes []string, err error)
+}
+
+// Scoper is implemented by trackers whose issues and searches depend on the repository or the board, e.g. GitHub issue numbers
+type Scoper interface {
+	// Scope identifies the repository, project or board used by the tracker, empty when it can't be found
+	Scope() string
 }
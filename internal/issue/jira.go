@@ -2,7 +2,8 @@ package issue
 
 import (
 	"context"
-	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -13,10 +14,12 @@ import (
 	"github.com/Ealenn/gira/internal/configuration"
 	"github.com/Ealenn/gira/internal/git"
 	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/network"
 
 	"github.com/ctreminiom/go-atlassian/v2/jira/agile"
 	v2 "github.com/ctreminiom/go-atlassian/v2/jira/v2"
 	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/v2/service/common"
 )
 
 type JiraTracker struct {
@@ -28,10 +31,10 @@ type JiraTracker struct {
 }
 
 func NewJira(logger *log.Logger, profile *configuration.Profile, git *git.Git) *JiraTracker {
-	baseClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
+	baseClient, err := network.NewClient(logger, profile.TLS)
+	if err != nil {
+		logger.Debug("HTTP client error: %v", err)
+		logger.Fatal("❌ Unable to configure TLS for %s", profile.Jira.Host)
 	}
 
 	client, err := v2.New(baseClient, profile.Jira.Host)
@@ -39,14 +42,14 @@ func NewJira(logger *log.Logger, profile *configuration.Profile, git *git.Git) *
 		logger.Debug("Jira client error: %v", err)
 		logger.Fatal("Unable to create Jira Client")
 	}
-	client.Auth.SetBearerToken(profile.Jira.Token)
+	setJiraAuth(client.Auth, profile.Jira)
 
 	agileClient, err := agile.New(baseClient, profile.Jira.Host)
 	if err != nil {
 		logger.Debug("Jira Agile client error: %v", err)
 		logger.Fatal("Unable to create Jira Agile Client")
 	}
-	agileClient.Auth.SetBearerToken(profile.Jira.Token)
+	setJiraAuth(agileClient.Auth, profile.Jira)
 
 	return &JiraTracker{
 		logger:     logger,
@@ -57,40 +60,121 @@ func NewJira(logger *log.Logger, profile *configuration.Profile, git *git.Git) *
 	}
 }
 
-func (tracker *JiraTracker) SearchIssues(status string) map[string]*Issue {
-	boardID, _ := strconv.Atoi(tracker.profile.Jira.Board)
-	issue, issueResponse, err := tracker.agilClient.Board.Issues(context.Background(), boardID, &models.IssueOptionScheme{
-		JQL: tracker.profile.Jira.JQL,
-	}, 0, 100)
-
-	if err != nil {
-		tracker.logger.Debug("Search issues error : %s", err)
-		tracker.logger.Debug("Search issues response status %s", issueResponse.Status)
-		tracker.logger.Fatal("❌ Unable to search issues")
+// setJiraAuth uses basic auth with email and API token for Jira Cloud, otherwise the token is sent as bearer (Data Center PAT or OAuth access token)
+func setJiraAuth(auth common.Authentication, settings configuration.Jira) {
+	switch settings.Auth {
+	case configuration.JiraAuthBasic:
+		auth.SetBasicAuth(settings.Email, settings.Token)
+	default:
+		auth.SetBearerToken(settings.Token)
 	}
+}
+
+func (tracker *JiraTracker) SearchIssues(options SearchOptions, onPage PageHandler) (map[string]*Issue, error) {
+	boardID, _ := strconv.Atoi(tracker.profile.Jira.Board)
+	maxResults := getMaxResults(tracker.profile)
+	jql := tracker.toJQL(options)
+	tracker.logger.Debug("Search issues with JQL %s", jql)
 
 	filteredIssues := make(map[string]*Issue)
-	for _, issue := range issue.Issues {
-		if strings.EqualFold(status, "all") || strings.EqualFold(status, issue.Fields.Status.Name) {
+	for startAt := 0; startAt < maxResults; {
+		page, issueResponse, err := tracker.agilClient.Board.Issues(context.Background(), boardID, &models.IssueOptionScheme{
+			JQL: jql,
+		}, startAt, min(searchPageSize, maxResults-startAt))
+
+		if err != nil {
+			tracker.logger.Debug("Search issues response status %d with error %v", tracker.statusCode(issueResponse), err)
+			return nil, wrapError(tracker.statusCode(issueResponse), err)
+		}
+
+		pageIssues := []*Issue{}
+		for _, issue := range page.Issues {
 			filteredIssues[issue.Key] = tracker.formatIssue(issue)
+			pageIssues = append(pageIssues, filteredIssues[issue.Key])
+		}
+		notifyPage(onPage, pageIssues)
+
+		startAt += len(page.Issues)
+		tracker.logger.Debug("Search issues %d/%d loaded", startAt, page.Total)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	return filteredIssues, nil
+}
+
+// toJQL adds the search options to the profile JQL, "open" and "closed" statuses use the status category
+func (tracker *JiraTracker) toJQL(options SearchOptions) string {
+	clauses := []string{}
+	orderBy := ""
+
+	profileJQL := tracker.profile.Jira.JQL
+	if index := strings.Index(strings.ToUpper(profileJQL), "ORDER BY"); index >= 0 {
+		orderBy = strings.TrimSpace(profileJQL[index:])
+		profileJQL = profileJQL[:index]
+	}
+	if strings.TrimSpace(profileJQL) != "" {
+		clauses = append(clauses, "("+strings.TrimSpace(profileJQL)+")")
+	}
+
+	switch status := strings.ToLower(options.Status); status {
+	case "", "all":
+	case "open":
+		clauses = append(clauses, "statusCategory != Done")
+	case "closed":
+		clauses = append(clauses, "statusCategory = Done")
+	default:
+		clauses = append(clauses, "status = "+tracker.quoteJQL(options.Status))
+	}
+
+	switch options.Assignee {
+	case "":
+	case AssigneeMe:
+		clauses = append(clauses, "assignee = currentUser()")
+	default:
+		clauses = append(clauses, "assignee = "+tracker.quoteJQL(options.Assignee))
+	}
+
+	if len(options.Types) > 0 {
+		types := []string{}
+		for _, issueType := range options.Types {
+			types = append(types, tracker.quoteJQL(issueType))
 		}
+		clauses = append(clauses, "issuetype in ("+strings.Join(types, ", ")+")")
 	}
 
-	return filteredIssues
+	if options.Query != "" {
+		clauses = append(clauses, "text ~ "+tracker.quoteJQL(options.Query))
+	}
+
+	if !options.UpdatedSince.IsZero() {
+		clauses = append(clauses, "updated >= "+tracker.quoteJQL(options.UpdatedSince.Format("2006-01-02 15:04")))
+	}
+
+	if options.Sort != "" {
+		orderBy = fmt.Sprintf("ORDER BY %s %s", options.Sort, strings.ToUpper(options.Order))
+	}
+
+	return strings.TrimSpace(strings.Join(clauses, " AND ") + " " + orderBy)
+}
+
+func (tracker *JiraTracker) quoteJQL(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
 }
 
-func (tracker *JiraTracker) GetIssue(issueKeyID string) *Issue {
+func (tracker *JiraTracker) GetIssue(issueKeyID string) (*Issue, error) {
 	issue, issueResponse, err := tracker.jiraClient.Issue.Get(context.Background(), issueKeyID, nil, nil)
 
 	if err != nil {
-		tracker.logger.Debug("Issue %s response status %s", issueKeyID, issueResponse.Status)
-		tracker.logger.Fatal("❌ Unable to find issue %s", issueKeyID)
+		tracker.logger.Debug("Issue %s response status %d with error %v", issueKeyID, tracker.statusCode(issueResponse), err)
+		return nil, wrapError(tracker.statusCode(issueResponse), err)
 	}
 
-	return tracker.formatIssue(issue)
+	return tracker.formatIssue(issue), nil
 }
 
-func (tracker *JiraTracker) CreateIssue(options CreateIssueOptions) *Issue {
+func (tracker *JiraTracker) CreateIssue(options CreateIssueOptions) (*Issue, error) {
 	issueTypeName := "Task"
 	if options.Type == TypeBug {
 		issueTypeName = "Bug"
@@ -106,8 +190,8 @@ func (tracker *JiraTracker) CreateIssue(options CreateIssueOptions) *Issue {
 	}, &models.CustomFields{})
 
 	if err != nil {
-		tracker.logger.Debug("Create issue response status %s with error %v", issueResponse.StatusCode, err)
-		tracker.logger.Fatal("❌ Unable to create issue due to %s", issueResponse.Status)
+		tracker.logger.Debug("Create issue response status %d with error %v", tracker.statusCode(issueResponse), err)
+		return nil, wrapError(tracker.statusCode(issueResponse), err)
 	}
 
 	tracker.logger.Debug("Issue ID %s and Key %s created", issue.ID, issue.Key)
@@ -115,13 +199,67 @@ func (tracker *JiraTracker) CreateIssue(options CreateIssueOptions) *Issue {
 }
 
 func (tracker *JiraTracker) GetMyself() (*models.UserScheme, error) {
-	user, _, userError := tracker.jiraClient.MySelf.Details(context.Background(), []string{})
+	user, userResponse, userError := tracker.jiraClient.MySelf.Details(context.Background(), []string{})
 	if userError != nil {
-		return nil, userError
+		return nil, wrapError(tracker.statusCode(userResponse), userError)
 	}
 	return user, nil
 }
 
+// Scope identifies the board and the JQL of the profile, they may be set by the repository project file
+func (tracker *JiraTracker) Scope() string {
+	return strings.Join([]string{tracker.profile.Jira.Host, tracker.profile.Jira.Board, tracker.toJQL(SearchOptions{})}, " ")
+}
+
+// Authenticate returns the display name of the token owner, Jira tokens have no scopes
+func (tracker *JiraTracker) Authenticate() (string, []string, error) {
+	user, err := tracker.GetMyself()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return user.DisplayName, nil, nil
+}
+
+// GetBoard returns the name of the board
+func (tracker *JiraTracker) GetBoard(board string) (string, error) {
+	boardID, err := strconv.Atoi(board)
+	if err != nil {
+		return "", fmt.Errorf("invalid board ID %s, expected a number", board)
+	}
+
+	boardDetails, boardResponse, err := tracker.agilClient.Board.Get(context.Background(), boardID)
+	if err != nil {
+		tracker.logger.Debug("Board %s response status %d with error %v", board, tracker.statusCode(boardResponse), err)
+		return "", wrapError(tracker.statusCode(boardResponse), err)
+	}
+
+	return boardDetails.Name, nil
+}
+
+// ValidateJQL runs the search of the board with the profile JQL, like the dash and list commands
+func (tracker *JiraTracker) ValidateJQL() error {
+	boardID, _ := strconv.Atoi(tracker.profile.Jira.Board)
+	_, searchResponse, err := tracker.agilClient.Board.Issues(context.Background(), boardID, &models.IssueOptionScheme{
+		JQL: tracker.toJQL(SearchOptions{}),
+	}, 0, 1)
+	if err == nil {
+		return nil
+	}
+
+	tracker.logger.Debug("Validate JQL response status %d with error %v", tracker.statusCode(searchResponse), err)
+	if tracker.statusCode(searchResponse) == http.StatusBadRequest {
+		var body struct {
+			ErrorMessages []string `json:"errorMessages"`
+		}
+		if json.Unmarshal(searchResponse.Bytes.Bytes(), &body) == nil && len(body.ErrorMessages) > 0 {
+			return errors.New(strings.Join(body.ErrorMessages, " "))
+		}
+	}
+
+	return wrapError(tracker.statusCode(searchResponse), err)
+}
+
 func (tracker *JiraTracker) SelfAssignIssue(issueKeyID string) error {
 	ctx := context.Background()
 
@@ -132,12 +270,12 @@ func (tracker *JiraTracker) SelfAssignIssue(issueKeyID string) error {
 
 	// For Jira Cloud, use AccountID
 	if user.AccountID != "" {
-		_, err := tracker.jiraClient.Issue.Assign(ctx, issueKeyID, user.AccountID)
-		return err
+		assignResponse, err := tracker.jiraClient.Issue.Assign(ctx, issueKeyID, user.AccountID)
+		return wrapError(tracker.statusCode(assignResponse), err)
 	}
 
 	// For Jira Server/Data Center, use User Key or Name
-	_, err := tracker.jiraClient.Issue.Update(ctx, issueKeyID, true, &models.IssueSchemeV2{
+	updateResponse, err := tracker.jiraClient.Issue.Update(ctx, issueKeyID, true, &models.IssueSchemeV2{
 		Fields: &models.IssueFieldsSchemeV2{
 			Assignee: &models.UserScheme{
 				Key:  user.Key,
@@ -146,7 +284,118 @@ func (tracker *JiraTracker) SelfAssignIssue(issueKeyID string) error {
 		},
 	}, nil, nil)
 
-	return err
+	return wrapError(tracker.statusCode(updateResponse), err)
+}
+
+func (tracker *JiraTracker) AddLabel(issueKeyID string, label string) error {
+	// Jira labels are single words
+	if strings.ContainsAny(label, " \t") {
+		return fmt.Errorf("labels can't contain spaces on Jira: %s", label)
+	}
+
+	operations := &models.UpdateOperations{}
+	if err := operations.AddArrayOperation("labels", map[string]string{label: "add"}); err != nil {
+		return err
+	}
+
+	updateResponse, err := tracker.jiraClient.Issue.Update(context.Background(), issueKeyID, true, &models.IssueSchemeV2{}, nil, operations)
+	if err != nil {
+		tracker.logger.Debug("Add label to %s response status %d with error %v", issueKeyID, tracker.statusCode(updateResponse), err)
+	}
+
+	return wrapError(tracker.statusCode(updateResponse), err)
+}
+
+func (tracker *JiraTracker) GetTransitions(issueKeyID string) ([]Transition, error) {
+	response, transitionsResponse, err := tracker.jiraClient.Issue.Transitions(context.Background(), issueKeyID)
+	if err != nil {
+		tracker.logger.Debug("Transitions of %s response status %d", issueKeyID, tracker.statusCode(transitionsResponse))
+		return nil, wrapError(tracker.statusCode(transitionsResponse), err)
+	}
+
+	transitions := []Transition{}
+	for _, transition := range response.Transitions {
+		status := transition.Name
+		if transition.To != nil && transition.To.Name != "" {
+			status = transition.To.Name
+		}
+
+		transitions = append(transitions, Transition{
+			ID:     transition.ID,
+			Name:   transition.Name,
+			Status: status,
+		})
+	}
+
+	return transitions, nil
+}
+
+func (tracker *JiraTracker) TransitionIssue(issueKeyID string, transition Transition) error {
+	moveResponse, err := tracker.jiraClient.Issue.Move(context.Background(), issueKeyID, transition.ID, nil)
+	if err != nil {
+		tracker.logger.Debug("Move %s to %s response status %d", issueKeyID, transition.Status, tracker.statusCode(moveResponse))
+	}
+
+	return wrapError(tracker.statusCode(moveResponse), err)
+}
+
+func (tracker *JiraTracker) GetComments(issueKeyID string) ([]Comment, error) {
+	comments := []Comment{}
+	for startAt := 0; ; {
+		page, commentsResponse, err := tracker.jiraClient.Issue.Comment.Gets(context.Background(), issueKeyID, "created", nil, startAt, searchPageSize)
+		if err != nil {
+			tracker.logger.Debug("Comments of %s response status %d with error %v", issueKeyID, tracker.statusCode(commentsResponse), err)
+			return nil, wrapError(tracker.statusCode(commentsResponse), err)
+		}
+
+		for _, comment := range page.Comments {
+			comments = append(comments, tracker.formatComment(comment))
+		}
+
+		startAt += len(page.Comments)
+		if len(page.Comments) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	return comments, nil
+}
+
+func (tracker *JiraTracker) AddComment(issueKeyID string, markdown string) (*Comment, error) {
+	comment, commentResponse, err := tracker.jiraClient.Issue.Comment.Add(context.Background(), issueKeyID, &models.CommentPayloadSchemeV2{
+		Body: tracker.toWiki(markdown),
+	}, nil)
+	if err != nil {
+		tracker.logger.Debug("Add comment to %s response status %d with error %v", issueKeyID, tracker.statusCode(commentResponse), err)
+		return nil, wrapError(tracker.statusCode(commentResponse), err)
+	}
+
+	formattedComment := tracker.formatComment(comment)
+	return &formattedComment, nil
+}
+
+func (tracker *JiraTracker) AddWorklog(issueKeyID string, started time.Time, spent time.Duration, comment string) error {
+	payload := &models.WorklogRichTextPayloadScheme{
+		Started:          started.Format("2006-01-02T15:04:05.000-0700"),
+		TimeSpentSeconds: int(spent.Round(time.Minute).Seconds()),
+	}
+	if comment != "" {
+		payload.Comment = &models.CommentPayloadSchemeV2{Body: tracker.toWiki(comment)}
+	}
+
+	_, worklogResponse, err := tracker.jiraClient.Issue.Worklog.Add(context.Background(), issueKeyID, payload, nil)
+	if err != nil {
+		tracker.logger.Debug("Add worklog to %s response status %d with error %v", issueKeyID, tracker.statusCode(worklogResponse), err)
+	}
+
+	return wrapError(tracker.statusCode(worklogResponse), err)
+}
+
+func (tracker *JiraTracker) statusCode(response *models.ResponseScheme) int {
+	if response == nil {
+		return 0
+	}
+	return response.Code
 }
 
 func (tracker *JiraTracker) formatIssue(issue *models.IssueSchemeV2) *Issue {
@@ -159,11 +408,17 @@ func (tracker *JiraTracker) formatIssue(issue *models.IssueSchemeV2) *Issue {
 		})
 	}
 
+	priority := ""
+	if issue.Fields.Priority != nil {
+		priority = issue.Fields.Priority.Name
+	}
+
 	return &Issue{
 		ID:          issue.Key,
 		Title:       issue.Fields.Summary,
 		Description: tracker.toMarkdown(issue.Fields.Description),
 		Status:      issue.Fields.Status.Name,
+		Priority:    priority,
 		Types:       []string{issue.Fields.IssueType.Name},
 		URL:         fmt.Sprintf("%s%s%s", tracker.profile.Jira.Host, "/browse/", issue.Key),
 		Assignees:   assignees,
@@ -171,8 +426,108 @@ func (tracker *JiraTracker) formatIssue(issue *models.IssueSchemeV2) *Issue {
 	}
 }
 
+func (tracker *JiraTracker) formatComment(comment *models.IssueCommentSchemeV2) Comment {
+	author := ""
+	if comment.Author != nil {
+		author = comment.Author.DisplayName
+	}
+
+	// Jira dates contain milliseconds (e.g. 2025-01-31T10:00:00.000+0000)
+	createdAt, _ := time.Parse("2006-01-02T15:04:05.000-0700", comment.Created)
+
+	return Comment{
+		ID:        comment.ID,
+		Author:    author,
+		Body:      tracker.toMarkdown(comment.Body),
+		CreatedAt: createdAt,
+	}
+}
+
 func (tracker *JiraTracker) toMarkdown(content string) string {
 	content = regexp.MustCompile(`\[(.*?)\|(.*?)\]`).ReplaceAllString(content, "$1 $2")
 	content = regexp.MustCompile(`\[(.*?)\]`).ReplaceAllString(content, "$1")
 	return content
 }
+
+var (
+	markdownHeading = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	markdownList    = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	markdownQuote   = regexp.MustCompile(`^>\s?(.*)$`)
+	markdownRule    = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	markdownImage   = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)[^)]*\)`)
+	markdownLink    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	markdownBold    = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	markdownItalic  = regexp.MustCompile(`\*([^*\s][^*]*?)\*`)
+	markdownStrike  = regexp.MustCompile(`~~(.+?)~~`)
+)
+
+// toWiki converts Markdown to Jira wiki markup (headings, lists, quotes, code, emphasis and links)
+func (tracker *JiraTracker) toWiki(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	inCode := false
+	for index, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			language := strings.TrimPrefix(trimmed, "```")
+			if !inCode && language != "" {
+				lines[index] = "{code:" + language + "}"
+			} else {
+				lines[index] = "{code}"
+			}
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+
+		switch {
+		case markdownRule.MatchString(line):
+			lines[index] = "----"
+		case markdownHeading.MatchString(line):
+			matches := markdownHeading.FindStringSubmatch(line)
+			lines[index] = fmt.Sprintf("h%d. %s", len(matches[1]), tracker.toWikiInline(matches[2]))
+		case markdownList.MatchString(line):
+			matches := markdownList.FindStringSubmatch(line)
+			bullet := "*"
+			if matches[2][0] >= '0' && matches[2][0] <= '9' {
+				bullet = "#"
+			}
+			depth := len(strings.ReplaceAll(matches[1], "\t", "  "))/2 + 1
+			lines[index] = strings.Repeat(bullet, depth) + " " + tracker.toWikiInline(matches[3])
+		case markdownQuote.MatchString(line):
+			lines[index] = "bq. " + tracker.toWikiInline(markdownQuote.FindStringSubmatch(line)[1])
+		default:
+			lines[index] = tracker.toWikiInline(line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// toWikiInline converts inline Markdown, inline code is kept as is between {{ }}
+func (tracker *JiraTracker) toWikiInline(text string) string {
+	parts := strings.Split(text, "`")
+	for index, part := range parts {
+		if index%2 == 1 {
+			if index == len(parts)-1 {
+				// Unclosed inline code
+				parts[index] = "`" + part
+			} else {
+				parts[index] = "{{" + part + "}}"
+			}
+			continue
+		}
+
+		part = markdownImage.ReplaceAllString(part, "!$2!")
+		part = markdownLink.ReplaceAllString(part, "[$1|$2]")
+		// Bold is marked with a placeholder to avoid converting it again as italic
+		part = markdownBold.ReplaceAllString(part, "\x00$1$2\x00")
+		part = markdownItalic.ReplaceAllString(part, "_${1}_")
+		part = markdownStrike.ReplaceAllString(part, "-$1-")
+		parts[index] = strings.ReplaceAll(part, "\x00", "*")
+	}
+
+	return strings.Join(parts, "")
+}
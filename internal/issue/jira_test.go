@@ -0,0 +1,85 @@
+package issue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ealenn/gira/internal/configuration"
+)
+
+func TestJiraToJQL(t *testing.T) {
+	since := time.Date(2025, 1, 31, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		profileJQL string
+		options    SearchOptions
+		want       string
+	}{
+		{"empty", "", SearchOptions{}, ""},
+		{"profile JQL", "project = ABC", SearchOptions{}, "(project = ABC)"},
+		{"open status", "", SearchOptions{Status: "open"}, "statusCategory != Done"},
+		{"closed status", "", SearchOptions{Status: "Closed"}, "statusCategory = Done"},
+		{"all status", "", SearchOptions{Status: "all"}, ""},
+		{"named status", "", SearchOptions{Status: `In "Review"`}, `status = "In \"Review\""`},
+		{"assignee me", "", SearchOptions{Assignee: AssigneeMe}, "assignee = currentUser()"},
+		{"assignee", "", SearchOptions{Assignee: "jdoe"}, `assignee = "jdoe"`},
+		{"types", "", SearchOptions{Types: []string{"Bug", "Story"}}, `issuetype in ("Bug", "Story")`},
+		{"query", "", SearchOptions{Query: "login"}, `text ~ "login"`},
+		{"updated since", "", SearchOptions{UpdatedSince: since}, `updated >= "2025-01-31 08:30"`},
+		{
+			"profile order kept",
+			"project = ABC ORDER BY rank",
+			SearchOptions{Status: "open"},
+			"(project = ABC) AND statusCategory != Done ORDER BY rank",
+		},
+		{
+			"sort replaces profile order",
+			"project = ABC order by rank",
+			SearchOptions{Sort: "updated", Order: "desc"},
+			"(project = ABC) ORDER BY updated DESC",
+		},
+		{"only order", "ORDER BY created", SearchOptions{}, "ORDER BY created"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := &JiraTracker{profile: &configuration.Profile{Jira: configuration.Jira{JQL: test.profileJQL}}}
+			if got := tracker.toJQL(test.options); got != test.want {
+				t.Errorf("toJQL() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestJiraToWiki(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"plain", "Hello world", "Hello world"},
+		{"headings", "# Title\n### Section", "h1. Title\nh3. Section"},
+		{"bullet list", "- one\n  - two\n* three", "* one\n** two\n* three"},
+		{"numbered list", "1. one\n2) two", "# one\n# two"},
+		{"quote", "> quoted **text**", "bq. quoted *text*"},
+		{"rule", "---", "----"},
+		{"bold and italic", "**bold** and *italic* and __bold__", "*bold* and _italic_ and *bold*"},
+		{"strike", "~~removed~~", "-removed-"},
+		{"link", "[Gira](https://github.com/Ealenn/gira)", "[Gira|https://github.com/Ealenn/gira]"},
+		{"image", "![logo](https://example.com/logo.png)", "!https://example.com/logo.png!"},
+		{"inline code", "run `gira **dash**` now", "run {{gira **dash**}} now"},
+		{"unclosed inline code", "a `b", "a `b"},
+		{"code block", "```go\nfunc **main**()\n```", "{code:go}\nfunc **main**()\n{code}"},
+		{"code block without language", "```\n# not a heading\n```", "{code}\n# not a heading\n{code}"},
+		{"windows line endings", "# Title\r\ntext", "h1. Title\ntext"},
+	}
+
+	tracker := &JiraTracker{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := tracker.toWiki(test.markdown); got != test.want {
+				t.Errorf("toWiki(%q) = %q, want %q", test.markdown, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package issue
+
+import "github.com/Ealenn/gira/internal/configuration"
+
+// DefaultMaxResults caps the number of issues fetched by a search when the profile doesn't set one
+const DefaultMaxResults = 500
+
+const searchPageSize = 100
+
+// PageHandler receives the issues of each page while a search is loading
+type PageHandler func(issues []*Issue)
+
+func getMaxResults(profile *configuration.Profile) int {
+	if profile.Dash.MaxResults > 0 {
+		return profile.Dash.MaxResults
+	}
+
+	return DefaultMaxResults
+}
+
+func notifyPage(onPage PageHandler, issues []*Issue) {
+	if onPage != nil && len(issues) > 0 {
+		onPage(issues)
+	}
+}
@@ -47,12 +47,16 @@ func (logger *Logger) Warn(format string, args ...any) {
 }
 
 func (logger *Logger) Fatal(format string, args ...any) {
+	logger.FatalWithCode(1, format, args...)
+}
+
+func (logger *Logger) FatalWithCode(code int, format string, args ...any) {
 	if *logger.verbose {
 		fmt.Print(ErrorStyle.Render("[FATAL] "))
 	}
 
 	write(ErrorStyle, format, args...)
-	os.Exit(0)
+	os.Exit(code)
 }
 
 func renderArgs(style lipgloss.Style, args ...any) []any {
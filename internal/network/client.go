@@ -0,0 +1,56 @@
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/Ealenn/gira/internal/configuration"
+	"github.com/Ealenn/gira/internal/log"
+)
+
+// NewClient returns an HTTP client verifying TLS certificates with the system roots and the optional CA bundle,
+// proxies are read from HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+func NewClient(logger *log.Logger, settings configuration.TLS) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if settings.CABundle != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			logger.Debug("Unable to load system certificates %v", err)
+			rootCAs = x509.NewCertPool()
+		}
+
+		caBundle, err := os.ReadFile(settings.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA bundle %s: %w", settings.CABundle, err)
+		}
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no PEM certificate found in CA bundle %s", settings.CABundle)
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if settings.ClientCertificate != "" || settings.ClientKey != "" {
+		certificate, err := tls.LoadX509KeyPair(settings.ClientCertificate, settings.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate %s: %w", settings.ClientCertificate, err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if settings.Insecure {
+		logger.Warn("⚠️  TLS certificate verification is %s, connections are not secure", "disabled")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: transport}, nil
+}
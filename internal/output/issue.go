@@ -0,0 +1,87 @@
+package output
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ealenn/gira/internal/issue"
+)
+
+func (printer *Printer) Issues(issues []*issue.Issue) error {
+	table := Table{Headers: []string{"ID", "STATUS", "TYPES", "ASSIGNEES", "TITLE"}}
+	for _, currentIssue := range issues {
+		table.Rows = append(table.Rows, []string{
+			currentIssue.ID,
+			currentIssue.Status,
+			strings.Join(currentIssue.Types, ","),
+			strings.Join(assigneeNames(currentIssue), ","),
+			currentIssue.Title,
+		})
+	}
+
+	return printer.Print(issues, table)
+}
+
+func (printer *Printer) Issue(currentIssue *issue.Issue) error {
+	table := Table{
+		Headers: []string{"FIELD", "VALUE"},
+		Rows: [][]string{
+			{"id", currentIssue.ID},
+			{"title", currentIssue.Title},
+			{"status", currentIssue.Status},
+			{"priority", currentIssue.Priority},
+			{"types", strings.Join(currentIssue.Types, ",")},
+			{"assignees", strings.Join(assigneeNames(currentIssue), ",")},
+			{"url", currentIssue.URL},
+			{"createdAt", currentIssue.CreatedAt.Format(time.RFC3339)},
+		},
+	}
+
+	return printer.Print(currentIssue, table)
+}
+
+// SortIssues returns the issues of a search sorted by ID, numbers are compared as numbers (ABC-9 before ABC-10)
+func SortIssues(issues map[string]*issue.Issue) []*issue.Issue {
+	sortedIssues := make([]*issue.Issue, 0, len(issues))
+	for _, currentIssue := range issues {
+		sortedIssues = append(sortedIssues, currentIssue)
+	}
+	slices.SortFunc(sortedIssues, func(a, b *issue.Issue) int {
+		return compareIDs(a.ID, b.ID)
+	})
+
+	return sortedIssues
+}
+
+func compareIDs(a, b string) int {
+	aPrefix, aNumber := splitID(a)
+	bPrefix, bNumber := splitID(b)
+
+	if prefix := strings.Compare(aPrefix, bPrefix); prefix != 0 {
+		return prefix
+	}
+	if aNumber != bNumber {
+		return aNumber - bNumber
+	}
+
+	return strings.Compare(a, b)
+}
+
+// splitID splits "ABC-123" in "ABC-" and 123, GitHub and GitLab IDs have no prefix
+func splitID(id string) (string, int) {
+	index := strings.LastIndexFunc(id, func(r rune) bool { return r < '0' || r > '9' }) + 1
+	number, _ := strconv.Atoi(id[index:])
+
+	return id[:index], number
+}
+
+func assigneeNames(currentIssue *issue.Issue) []string {
+	names := []string{}
+	for _, assignee := range currentIssue.Assignees {
+		names = append(names, assignee.Name)
+	}
+
+	return names
+}
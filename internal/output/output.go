@@ -0,0 +1,97 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatTable Format = "table"
+	FormatPlain Format = "plain"
+)
+
+var Formats = []Format{FormatJSON, FormatYAML, FormatTable, FormatPlain}
+
+func ParseFormat(value string) (Format, error) {
+	for _, format := range Formats {
+		if strings.EqualFold(value, string(format)) {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown output format %s", value)
+}
+
+// IsTerminal reports whether stdout is an interactive terminal, TUIs are only started in this case
+func IsTerminal() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Table is the text representation of a value for table and plain formats
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+type Printer struct {
+	format Format
+	writer io.Writer
+}
+
+func NewPrinter(format Format) *Printer {
+	return &Printer{
+		format,
+		os.Stdout,
+	}
+}
+
+// Print writes the value as JSON or YAML, or the table for table (aligned with headers) and plain (tab separated) formats
+func (printer *Printer) Print(value any, table Table) error {
+	switch printer.format {
+	case FormatJSON:
+		encoder := json.NewEncoder(printer.writer)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(printer.writer)
+		encoder.SetIndent(2)
+		defer encoder.Close()
+		return encoder.Encode(value)
+	case FormatTable:
+		writer := tabwriter.NewWriter(printer.writer, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, strings.Join(table.Headers, "\t"))
+		for _, row := range table.Rows {
+			fmt.Fprintln(writer, strings.Join(printer.clean(row), "\t"))
+		}
+		return writer.Flush()
+	default:
+		for _, row := range table.Rows {
+			if _, err := fmt.Fprintln(printer.writer, strings.Join(printer.clean(row), "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// clean keeps one row per line, tabs and new lines are replaced by spaces
+func (printer *Printer) clean(row []string) []string {
+	cleaned := make([]string, len(row))
+	for index, cell := range row {
+		cleaned[index] = strings.Join(strings.Fields(cell), " ")
+	}
+
+	return cleaned
+}
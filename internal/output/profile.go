@@ -0,0 +1,79 @@
+package output
+
+import (
+	"encoding/json"
+
+	"github.com/Ealenn/gira/internal/configuration"
+)
+
+// Profile is the public part of a profile, tokens are never printed
+type Profile struct {
+	Name string                    `json:"name" yaml:"name"`
+	Type configuration.ProfileType `json:"type" yaml:"type"`
+	Host string                    `json:"host,omitempty" yaml:"host,omitempty"`
+	User string                    `json:"user,omitempty" yaml:"user,omitempty"`
+}
+
+func (printer *Printer) Profiles(profiles []configuration.Profile) error {
+	publicProfiles := []Profile{}
+	table := Table{Headers: []string{"NAME", "TYPE", "HOST", "USER"}}
+	for _, profile := range profiles {
+		publicProfile := Profile{Name: profile.Name, Type: profile.Type}
+		switch profile.Type {
+		case configuration.ProfileTypeJira:
+			publicProfile.Host = profile.Jira.Host
+			publicProfile.User = profile.Jira.Email
+		case configuration.ProfileTypeGithub:
+			publicProfile.Host = profile.Github.BaseURL
+			publicProfile.User = profile.Github.User
+		case configuration.ProfileTypeGitlab:
+			publicProfile.Host = profile.Gitlab.Host
+		}
+
+		publicProfiles = append(publicProfiles, publicProfile)
+		table.Rows = append(table.Rows, []string{publicProfile.Name, string(publicProfile.Type), publicProfile.Host, publicProfile.User})
+	}
+
+	return printer.Print(publicProfiles, table)
+}
+
+// ProfileExport prints every setting of the profiles, in the format of the configuration file for JSON and YAML
+func (printer *Printer) ProfileExport(profiles []configuration.Profile) error {
+	table := Table{Headers: []string{"PROFILE", "FIELD", "VALUE"}}
+	for _, profile := range profiles {
+		for _, path := range configuration.Paths() {
+			if value, _ := profile.Get(path); value != "" {
+				table.Rows = append(table.Rows, []string{profile.Name, path, value})
+			}
+		}
+	}
+
+	// Go through JSON to use the field names of the configuration file in YAML
+	rawContent, err := json.Marshal(configuration.JSONConfiguration{Profiles: profiles})
+	if err != nil {
+		return err
+	}
+	var content struct {
+		Profiles []map[string]any `json:"profiles" yaml:"profiles"`
+	}
+	if err := json.Unmarshal(rawContent, &content); err != nil {
+		return err
+	}
+	for _, profile := range content.Profiles {
+		removeEmptyObjects(profile)
+	}
+
+	return printer.Print(content, table)
+}
+
+// removeEmptyObjects removes the unused sections of a profile, like github for GitLab profiles
+func removeEmptyObjects(object map[string]any) {
+	for key, value := range object {
+		if child, ok := value.(map[string]any); ok {
+			removeEmptyObjects(child)
+			if len(child) == 0 {
+				delete(object, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package secret
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Prefixes of the secret references stored in the configuration instead of tokens
+const (
+	PrefixKeyring = "keyring:" // keyring:<profile>/<field>, OS keyring (Secret Service, macOS Keychain, Windows Credential Manager)
+	PrefixEnv     = "env:"     // env:<VARIABLE>, environment variable
+	PrefixCommand = "cmd:"     // cmd:<command>, credential helper printing the secret (e.g. pass show gira/jira, op read op://...)
+)
+
+// KeyringService is the service name of the Gira secrets in the OS keyring
+const KeyringService = "gira"
+
+var notAlphanumeric = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// IsReference returns true when the value points to a secret backend instead of being a plaintext secret
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, PrefixKeyring) || strings.HasPrefix(value, PrefixEnv) || strings.HasPrefix(value, PrefixCommand)
+}
+
+// Resolve returns the secret of a reference, plaintext values are returned as is
+func Resolve(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, PrefixKeyring):
+		key := strings.TrimPrefix(value, PrefixKeyring)
+		secret, err := keyring.Get(KeyringService, key)
+		if err != nil {
+			return "", fmt.Errorf("unable to read %s from the OS keyring: %w", key, err)
+		}
+		return secret, nil
+	case strings.HasPrefix(value, PrefixEnv):
+		variable := strings.TrimPrefix(value, PrefixEnv)
+		secret, ok := os.LookupEnv(variable)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", variable)
+		}
+		return secret, nil
+	case strings.HasPrefix(value, PrefixCommand):
+		return runHelper(strings.TrimPrefix(value, PrefixCommand))
+	default:
+		return value, nil
+	}
+}
+
+// Store saves a plaintext secret in the OS keyring and returns its reference, references are returned as is
+func Store(key string, value string) (string, error) {
+	if value == "" || IsReference(value) {
+		return value, nil
+	}
+
+	if err := keyring.Set(KeyringService, key, value); err != nil {
+		return "", fmt.Errorf("unable to write %s to the OS keyring: %w", key, err)
+	}
+
+	return PrefixKeyring + key, nil
+}
+
+// Delete removes the secret of a keyring reference, other references are managed outside of Gira
+func Delete(reference string) error {
+	if !strings.HasPrefix(reference, PrefixKeyring) {
+		return nil
+	}
+
+	err := keyring.Delete(KeyringService, strings.TrimPrefix(reference, PrefixKeyring))
+	if err == keyring.ErrNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// EnvVariable returns the name of the environment variable overriding a secret, e.g. GIRA_WORK_TOKEN
+func EnvVariable(profileName string, suffix string) string {
+	return "GIRA_" + strings.Trim(notAlphanumeric.ReplaceAllString(strings.ToUpper(profileName), "_"), "_") + "_" + suffix
+}
+
+// runHelper runs a credential helper, stdin and stderr are kept to let it prompt for a passphrase
+func runHelper(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("credential helper %s failed: %w", command, err)
+	}
+
+	// Helpers like pass print extra lines after the secret
+	secret, _, _ := strings.Cut(string(output), "\n")
+	return strings.TrimSpace(secret), nil
+}
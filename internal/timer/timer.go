@@ -0,0 +1,114 @@
+package timer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/Ealenn/gira/internal/log"
+)
+
+var ErrAlreadyRunning = errors.New("timer already running")
+var ErrNotRunning = errors.New("no timer running")
+
+type Timer struct {
+	Profile   string    `json:"profile"`
+	Scope     string    `json:"scope,omitempty"` // Repository or board of the tracker, GitHub and GitLab issue numbers are reused across repositories
+	IssueID   string    `json:"issue"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+type jsonTimers struct {
+	Timers []Timer `json:"timers"`
+}
+
+// Store keeps running timers in a local file next to the configuration, one timer per profile, tracker scope and issue
+type Store struct {
+	logger *log.Logger
+	path   string
+}
+
+func NewStore(logger *log.Logger) *Store {
+	homeDirPath, homeDirPathError := os.UserHomeDir()
+	if homeDirPathError != nil {
+		logger.Fatal("unable to find home directory %v", homeDirPathError)
+	}
+
+	return &Store{
+		logger,
+		filepath.Join(homeDirPath, ".gira-timers"),
+	}
+}
+
+func (store *Store) List() ([]Timer, error) {
+	rawFileContent, err := os.ReadFile(store.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Timer{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fileContent jsonTimers
+	if err := json.Unmarshal(rawFileContent, &fileContent); err != nil {
+		return nil, fmt.Errorf("invalid timers file %s: %w", store.path, err)
+	}
+
+	return fileContent.Timers, nil
+}
+
+func (store *Store) Start(profile string, scope string, issueID string) (*Timer, error) {
+	timers, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	if index := store.IndexOf(timers, profile, scope, issueID); index >= 0 {
+		return &timers[index], ErrAlreadyRunning
+	}
+
+	timer := Timer{
+		Profile:   profile,
+		Scope:     scope,
+		IssueID:   issueID,
+		StartedAt: time.Now(),
+	}
+
+	return &timer, store.save(append(timers, timer))
+}
+
+func (store *Store) Stop(profile string, scope string, issueID string) (*Timer, error) {
+	timers, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	index := store.IndexOf(timers, profile, scope, issueID)
+	if index < 0 {
+		return nil, ErrNotRunning
+	}
+
+	timer := timers[index]
+	return &timer, store.save(slices.Delete(timers, index, index+1))
+}
+
+// IndexOf returns the index of the timer of the issue, timers saved without scope match every scope
+func (store *Store) IndexOf(timers []Timer, profile string, scope string, issueID string) int {
+	return slices.IndexFunc(timers, func(timer Timer) bool {
+		return timer.Profile == profile && (timer.Scope == "" || timer.Scope == scope) && timer.IssueID == issueID
+	})
+}
+
+func (store *Store) save(timers []Timer) error {
+	jsonFileContent, err := json.Marshal(jsonTimers{Timers: timers})
+	if err != nil {
+		return fmt.Errorf("failed to marshal timers : %v", err)
+	}
+
+	store.logger.Debug("Save %d timers in %s", len(timers), store.path)
+	return os.WriteFile(store.path, jsonFileContent, 0600)
+}
@@ -0,0 +1,70 @@
+package timer
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Ealenn/gira/internal/log"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	verbose := false
+	return &Store{log.New(&verbose), filepath.Join(t.TempDir(), ".gira-timers")}
+}
+
+func TestStoreScopes(t *testing.T) {
+	store := newTestStore(t)
+
+	if _, err := store.Start("oss", "github.com/acme/api", "42"); err != nil {
+		t.Fatalf("Start() returned %v", err)
+	}
+	if _, err := store.Start("oss", "github.com/acme/web", "42"); err != nil {
+		t.Errorf("Start() of the same issue number in another repository returned %v", err)
+	}
+	if _, err := store.Start("oss", "github.com/acme/api", "42"); !errors.Is(err, ErrAlreadyRunning) {
+		t.Errorf("Start() of a running timer returned %v, want %v", err, ErrAlreadyRunning)
+	}
+
+	timer, err := store.Stop("oss", "github.com/acme/web", "42")
+	if err != nil {
+		t.Fatalf("Stop() returned %v", err)
+	}
+	if timer.Scope != "github.com/acme/web" {
+		t.Errorf("Stop() stopped the timer of %s, want github.com/acme/web", timer.Scope)
+	}
+	if _, err := store.Stop("oss", "github.com/acme/web", "42"); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Stop() of a stopped timer returned %v, want %v", err, ErrNotRunning)
+	}
+}
+
+func TestStoreIndexOf(t *testing.T) {
+	store := newTestStore(t)
+	timers := []Timer{
+		{Profile: "oss", Scope: "github.com/acme/api", IssueID: "42"},
+		{Profile: "work", IssueID: "ABC-1"},
+	}
+
+	tests := []struct {
+		name    string
+		profile string
+		scope   string
+		issueID string
+		want    int
+	}{
+		{"same scope", "oss", "github.com/acme/api", "42", 0},
+		{"other scope", "oss", "github.com/acme/web", "42", -1},
+		{"other profile", "work", "github.com/acme/api", "42", -1},
+		{"timer saved without scope", "work", "jira.acme.com 12", "ABC-1", 1},
+		{"other issue", "work", "jira.acme.com 12", "ABC-2", -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if index := store.IndexOf(timers, test.profile, test.scope, test.issueID); index != test.want {
+				t.Errorf("IndexOf() = %d, want %d", index, test.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,12 @@ import (
 	"github.com/Ealenn/gira/internal/configuration"
 )
 
+func CheckConfigurationFile(logger *log.Logger, configuration *configuration.Configuration) {
+	if configuration.Err != nil {
+		logger.Fatal("⚠️  %s\nPlease run the %s command or change your configuration here %s", "Configuration is invalid", "gira doctor", configuration.Path)
+	}
+}
+
 func CheckConfiguration(logger *log.Logger, configuration *configuration.Configuration, profileName string, profile *configuration.Profile) {
 	if profile == nil {
 		if profileName == "default" {
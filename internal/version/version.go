@@ -5,7 +5,9 @@ import (
 	_ "embed"
 	"strings"
 
+	"github.com/Ealenn/gira/internal/configuration"
 	"github.com/Ealenn/gira/internal/log"
+	"github.com/Ealenn/gira/internal/network"
 
 	"github.com/google/go-github/v73/github"
 )
@@ -19,7 +21,13 @@ type Version struct {
 }
 
 func New(logger *log.Logger) *Version {
-	githubClient := github.NewClient(nil)
+	// Releases are public on github.com, profile TLS settings are not needed
+	httpClient, err := network.NewClient(logger, configuration.TLS{})
+	if err != nil {
+		logger.Debug("HTTP client error: %v", err)
+	}
+
+	githubClient := github.NewClient(httpClient)
 
 	return &Version{
 		logger,
@@ -35,7 +43,9 @@ func (version *Version) GetLatestRelease() (*github.RepositoryRelease, error) {
 	release, releaseResponse, err := version.githubClient.Repositories.GetLatestRelease(context.Background(), "ealenn", "gira")
 
 	if err != nil {
-		version.logger.Debug("Unable to fetch Github release due to %s", releaseResponse.Status)
+		if releaseResponse != nil {
+			version.logger.Debug("Unable to fetch Github release due to %s", releaseResponse.Status)
+		}
 		return nil, err
 	}
 